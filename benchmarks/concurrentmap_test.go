@@ -0,0 +1,49 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package benchmarks_test
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/glasket/datastructures/collection/concurrentmap"
+)
+
+const MAP_KEYS int = 1 << 12
+
+func BenchmarkConcurrentMapWriteHeavy(b *testing.B) {
+	m := concurrentmap.New[int, int](0)
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			k := r.Intn(MAP_KEYS)
+			if r.Intn(4) == 0 {
+				m.Load(k)
+			} else {
+				m.Store(k, k)
+			}
+		}
+	})
+}
+
+func BenchmarkSyncMapWriteHeavy(b *testing.B) {
+	var m sync.Map
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			k := r.Intn(MAP_KEYS)
+			if r.Intn(4) == 0 {
+				m.Load(k)
+			} else {
+				m.Store(k, k)
+			}
+		}
+	})
+}
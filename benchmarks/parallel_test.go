@@ -0,0 +1,103 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package benchmarks_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+const SKEWED_SIZE int = 1 << 14
+
+var strategies = []struct {
+	name     string
+	strategy enumerator.Strategy
+}{
+	{"Static", enumerator.StrategyStatic},
+	{"Dynamic", enumerator.StrategyDynamic},
+}
+
+// spin does an amount of work proportional to n.
+func spin(n int) int {
+	acc := 0
+	for i := 0; i < n; i++ {
+		acc += i ^ (acc >> 1)
+	}
+	return acc
+}
+
+// skewedCost makes the first eighth of the values much more expensive than the rest,
+// which all lands in one partition under StrategyStatic.
+func skewedCost(v int) int {
+	if v < SKEWED_SIZE/8 {
+		return spin(4096)
+	}
+	return spin(16)
+}
+
+func BenchmarkParallelMapSkewed(b *testing.B) {
+	e := enumerator.Range(0, SKEWED_SIZE)
+	for _, s := range strategies {
+		b.Run(s.name, func(b *testing.B) {
+			p := enumerator.Parallel(e).WithStrategy(s.strategy)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = enumerator.ParallelMap[int](p, skewedCost)
+			}
+		})
+	}
+}
+
+func BenchmarkParallelFilterSkewed(b *testing.B) {
+	e := enumerator.Range(0, SKEWED_SIZE)
+	for _, s := range strategies {
+		b.Run(s.name, func(b *testing.B) {
+			p := enumerator.Parallel(e).WithStrategy(s.strategy)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = enumerator.ParallelFilter[int](p, func(v int) bool {
+					return skewedCost(v)%2 == 0
+				})
+			}
+		})
+	}
+}
+
+func BenchmarkParallelMapUniform(b *testing.B) {
+	e := enumerator.Range(0, SKEWED_SIZE)
+	for _, s := range strategies {
+		b.Run(s.name, func(b *testing.B) {
+			p := enumerator.Parallel(e).WithStrategy(s.strategy)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = enumerator.ParallelMap[int](p, func(v int) int {
+					return spin(16)
+				})
+			}
+		})
+	}
+}
+
+func BenchmarkParallelSort(b *testing.B) {
+	e := enumerator.GetSliceEnumerable(rand.Perm(1 << 20))
+	b.Run("Sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s := slices.Clone(e.Values())
+			slices.Sort(s)
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = enumerator.ParallelSort(e)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package benchmarks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glasket/datastructures/collection/queue/lockfree"
+)
+
+const QUEUE_SIZE int = 1024
+
+// Each parallel goroutine both produces and consumes, so the queue never
+// fills or empties for long.
+func BenchmarkLockfreeQueue(b *testing.B) {
+	q := lockfree.New[int](QUEUE_SIZE)
+	ctx := context.Background()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Enqueue(ctx, 1)
+			q.Dequeue(ctx)
+		}
+	})
+}
+
+func BenchmarkChannelQueue(b *testing.B) {
+	ch := make(chan int, QUEUE_SIZE)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- 1
+			<-ch
+		}
+	})
+}
+
+func BenchmarkLockfreeQueueTry(b *testing.B) {
+	q := lockfree.New[int](QUEUE_SIZE)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if !q.TryEnqueue(1) {
+				q.TryDequeue()
+			}
+			q.TryDequeue()
+		}
+	})
+}
+
+func BenchmarkChannelQueueTry(b *testing.B) {
+	ch := make(chan int, QUEUE_SIZE)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			select {
+			case ch <- 1:
+			default:
+				<-ch
+			}
+			select {
+			case <-ch:
+			default:
+			}
+		}
+	})
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package concurrent
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/glasket/datastructures/collection/list"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ list.IList[int] = (*List[int])(nil)
+
+// List guards a list.IList with a sync.RWMutex so it can be shared between goroutines.
+type List[V comparable] struct {
+	mu   sync.RWMutex
+	list list.IList[V]
+}
+
+// NewList wraps l. l must not be used directly once it has been wrapped.
+func NewList[V comparable](l list.IList[V]) *List[V] {
+	return &List[V]{list: l}
+}
+
+// Add appends the value to the list.
+func (l *List[V]) Add(v V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.list.Add(v)
+}
+
+// AddIfAbsent appends the value to the list and returns true if it was not already present.
+func (l *List[V]) AddIfAbsent(v V) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.list.Contains(v) {
+		return false
+	}
+	l.list.Add(v)
+	return true
+}
+
+// Remove removes the first occurrence of the value from the list.
+func (l *List[V]) Remove(v V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.list.Remove(v)
+}
+
+// RemoveIf removes every value for which f returns true and returns the number removed.
+//
+// f is called with the lock held and must not use the list.
+//
+// The kept values are collected in one pass and the list is rebuilt from
+// them, so RemoveIf takes O(n) whether the list is indexed or linked.
+func (l *List[V]) RemoveIf(f func(V) bool) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	values := l.list.Values()
+	kept := make([]V, 0, len(values))
+	for _, v := range values {
+		if !f(v) {
+			kept = append(kept, v)
+		}
+	}
+	if len(kept) == len(values) {
+		return 0
+	}
+	l.list.Clear()
+	for _, v := range kept {
+		l.list.Add(v)
+	}
+	return len(values) - len(kept)
+}
+
+// Clear removes all values from the list.
+func (l *List[V]) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.list.Clear()
+}
+
+// Get returns the value at the index.
+func (l *List[V]) Get(i int) (V, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Get(i)
+}
+
+// Set replaces the value at the index.
+func (l *List[V]) Set(i int, v V) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Set(i, v)
+}
+
+// InsertAt inserts the value at the index.
+func (l *List[V]) InsertAt(i int, v V) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.InsertAt(i, v)
+}
+
+// RemoveAt removes the value at the index.
+func (l *List[V]) RemoveAt(i int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.RemoveAt(i)
+}
+
+// IndexOf returns the index of the first occurrence of the value.
+func (l *List[V]) IndexOf(v V) (int, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.IndexOf(v)
+}
+
+// Contains returns true if the value is present in the list.
+func (l *List[V]) Contains(v V) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Contains(v)
+}
+
+// Count returns the number of values in the list.
+func (l *List[V]) Count() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Count()
+}
+
+// IsEmpty returns true if the list is empty.
+func (l *List[V]) IsEmpty() bool {
+	return l.Count() == 0
+}
+
+// String returns the string representation of the list.
+func (l *List[V]) String() string {
+	return fmt.Sprintf("ConcurrentList%v", l.Values())
+}
+
+// Values returns a copy of the values in the list.
+func (l *List[V]) Values() []V {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return slices.Clone(l.list.Values())
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over a snapshot of the list.
+//
+// The enumerator is unaffected by later changes to the list.
+func (l *List[V]) GetEnumerator() enumerator.IEnumerator[V] {
+	return enumerator.GetSliceEnumerable(l.Values()).GetEnumerator()
+}
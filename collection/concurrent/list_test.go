@@ -0,0 +1,64 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package concurrent_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	. "github.com/glasket/datastructures/collection/concurrent"
+	"github.com/glasket/datastructures/collection/list/arraylist"
+	"github.com/glasket/datastructures/collection/list/linkedlist"
+)
+
+func TestListConcurrentWriters(t *testing.T) {
+	l := NewList[int](arraylist.New[int]())
+	var wg sync.WaitGroup
+	for w := 0; w < WRITERS; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				l.Add(w*100 + i)
+				l.AddIfAbsent(i)
+				l.Get(0)
+			}
+		}(w)
+	}
+	wg.Wait()
+	if l.Count() < WRITERS*100 {
+		t.Errorf("Expected at least %d values, got %d", WRITERS*100, l.Count())
+	}
+}
+
+func TestListRemoveIf(t *testing.T) {
+	l := NewList[int](linkedlist.NewFromSlice([]int{1, 2, 3, 4, 5, 6}))
+	if !l.AddIfAbsent(7) || l.AddIfAbsent(1) {
+		t.Error("Expected AddIfAbsent to add only absent values")
+	}
+	removed := l.RemoveIf(func(i int) bool { return i%3 == 0 })
+	if removed != 2 || !reflect.DeepEqual(l.Values(), []int{1, 2, 4, 5, 7}) {
+		t.Errorf("Expected RemoveIf to remove [3 6], removed %d leaving %v", removed, l.Values())
+	}
+
+	if removed := l.RemoveIf(func(i int) bool { return i > 10 }); removed != 0 || l.Count() != 5 {
+		t.Errorf("Expected RemoveIf to remove nothing, removed %d", removed)
+	}
+
+	e := l.GetEnumerator()
+	l.RemoveAt(0)
+	values := make([]int, 0)
+	for e.Next() {
+		values = append(values, e.Current())
+	}
+	if !reflect.DeepEqual(values, []int{1, 2, 4, 5, 7}) {
+		t.Errorf("Expected the enumerator to see a snapshot, got %v", values)
+	}
+}
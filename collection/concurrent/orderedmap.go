@@ -0,0 +1,212 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package concurrent
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/glasket/datastructures/collection/orderedmap"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ enumerator.IEnumerable[orderedmap.Entry[int, int]] = (*OrderedMap[int, int])(nil)
+var _ json.Marshaler = (*OrderedMap[int, int])(nil)
+var _ json.Unmarshaler = (*OrderedMap[int, int])(nil)
+
+// OrderedMap guards an orderedmap.OrderedMap with a sync.RWMutex so it can be shared between goroutines.
+type OrderedMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  orderedmap.OrderedMap[K, V]
+}
+
+// NewOrderedMap creates an empty map with room for size keys.
+func NewOrderedMap[K comparable, V any](size int) *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{m: orderedmap.NewOrderedMap[K, V](size)}
+}
+
+// Keys returns the keys of the map in order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Keys()
+}
+
+// Values returns the entries of the map in order.
+func (m *OrderedMap[K, V]) Values() []orderedmap.Entry[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Values()
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over a snapshot of the entries in order.
+//
+// The enumerator is unaffected by later changes to the map.
+func (m *OrderedMap[K, V]) GetEnumerator() enumerator.IEnumerator[orderedmap.Entry[K, V]] {
+	return enumerator.GetSliceEnumerable(m.Values()).GetEnumerator()
+}
+
+// Get returns the value assigned to the key, or an error if the key is not present.
+func (m *OrderedMap[K, V]) Get(key K) (V, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Get(key)
+}
+
+// First returns the first entry, or an error if the map is empty.
+func (m *OrderedMap[K, V]) First() (orderedmap.Entry[K, V], error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.First()
+}
+
+// Last returns the last entry, or an error if the map is empty.
+func (m *OrderedMap[K, V]) Last() (orderedmap.Entry[K, V], error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Last()
+}
+
+// Set assigns the value to the key. A new key is added at the end of the order.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.Set(key, value)
+}
+
+// SetAndUpdate assigns the value to the key and moves the key to the end of the order.
+func (m *OrderedMap[K, V]) SetAndUpdate(key K, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.SetAndUpdate(key, value)
+}
+
+// ComputeIfAbsent returns the value assigned to the key, first assigning f(key)
+// to it if the key is not present.
+//
+// f is called with the lock held and must not use the map.
+func (m *OrderedMap[K, V]) ComputeIfAbsent(key K, f func(K) V) V {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if v, err := m.m.Get(key); err == nil {
+		return v
+	}
+	v := f(key)
+	m.m.Set(key, v)
+	return v
+}
+
+// RemoveIf removes every entry for which f returns true and returns the number removed.
+//
+// f is called with the lock held and must not use the map.
+func (m *OrderedMap[K, V]) RemoveIf(f func(K, V) bool) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]K, 0)
+	for k, v := range m.m.All() {
+		if f(k, v) {
+			keys = append(keys, k)
+		}
+	}
+	for _, k := range keys {
+		m.m.Remove(k)
+	}
+	return len(keys)
+}
+
+// MoveToEnd moves the key to the end of the order.
+//
+// Returns an error if the key was not assigned.
+func (m *OrderedMap[K, V]) MoveToEnd(key K) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.MoveToEnd(key)
+}
+
+// MoveToFront moves the key to the front of the order.
+//
+// Returns an error if the key was not assigned.
+func (m *OrderedMap[K, V]) MoveToFront(key K) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.MoveToFront(key)
+}
+
+// InsertBefore assigns the value to the key and positions the key immediately before mark.
+//
+// Returns an error if mark was not assigned.
+func (m *OrderedMap[K, V]) InsertBefore(mark K, key K, value V) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.InsertBefore(mark, key, value)
+}
+
+// InsertAfter assigns the value to the key and positions the key immediately after mark.
+//
+// Returns an error if mark was not assigned.
+func (m *OrderedMap[K, V]) InsertAfter(mark K, key K, value V) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.InsertAfter(mark, key, value)
+}
+
+// Remove removes the key and its assigned value.
+//
+// Returns an error if the key was not assigned.
+func (m *OrderedMap[K, V]) Remove(key K) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.Remove(key)
+}
+
+// Clear removes all keys from the map.
+func (m *OrderedMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.Clear()
+}
+
+// Contains returns true if the key is present in the map.
+func (m *OrderedMap[K, V]) Contains(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Contains(key)
+}
+
+// Count returns the number of keys in the map.
+func (m *OrderedMap[K, V]) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Count()
+}
+
+// IsEmpty returns true if the map is empty.
+func (m *OrderedMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// String returns the string representation of the map.
+func (m *OrderedMap[K, V]) String() string {
+	return fmt.Sprintf("ConcurrentOrderedMap%v", m.Values())
+}
+
+// MarshalJSON encodes the map as a JSON object with its keys in order.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.MarshalJSON()
+}
+
+// UnmarshalJSON decodes a JSON object into the map, see orderedmap.OrderedMap.UnmarshalJSON.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.UnmarshalJSON(data)
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package concurrent_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/glasket/datastructures/collection/concurrent"
+)
+
+func TestOrderedMapComputeIfAbsent(t *testing.T) {
+	m := NewOrderedMap[int, int](0)
+	calls := atomic.Int64{}
+	var wg sync.WaitGroup
+	for w := 0; w < WRITERS; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				v := m.ComputeIfAbsent(i, func(k int) int {
+					calls.Add(1)
+					return k * k
+				})
+				if v != i*i {
+					t.Errorf("Expected ComputeIfAbsent to return %d, got %d", i*i, v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 100 || m.Count() != 100 {
+		t.Errorf("Expected each key to be computed once, got %d calls and %d keys", calls.Load(), m.Count())
+	}
+
+	removed := m.RemoveIf(func(k, v int) bool { return k >= 10 })
+	if removed != 90 || m.Count() != 10 {
+		t.Errorf("Expected RemoveIf to remove 90 keys, removed %d", removed)
+	}
+	if last, _ := m.Last(); last.Key != 9 {
+		t.Errorf("Expected the last key to be 9, got %d", last.Key)
+	}
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	m := NewOrderedMap[string, int](0)
+	m.Set("b", 1)
+	m.Set("a", 2)
+	m.SetAndUpdate("b", 3)
+
+	data, err := json.Marshal(m)
+	if err != nil || string(data) != `{"a":2,"b":3}` {
+		t.Errorf("Expected Marshal to return {\"a\":2,\"b\":3}, got %s and %v", data, err)
+	}
+
+	var m2 OrderedMap[string, int]
+	if err := json.Unmarshal(data, &m2); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(m2.Keys(), []string{"a", "b"}) {
+		t.Errorf("Expected Unmarshal to keep key order, got %v", m2.Keys())
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package concurrent
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/glasket/datastructures/collection/set"
+	"github.com/glasket/datastructures/collection/set/hashset"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ set.ISet[int] = (*Set[int])(nil)
+
+// Set guards a set.ISet with a sync.RWMutex so it can be shared between goroutines.
+type Set[V comparable] struct {
+	mu  sync.RWMutex
+	set set.ISet[V]
+}
+
+// NewSet wraps s. s must not be used directly once it has been wrapped.
+func NewSet[V comparable](s set.ISet[V]) *Set[V] {
+	return &Set[V]{set: s}
+}
+
+// Add adds the value to the set.
+func (s *Set[V]) Add(v V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Add(v)
+}
+
+// AddIfAbsent adds the value to the set and returns true if it was not already present.
+func (s *Set[V]) AddIfAbsent(v V) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set.Contains(v) {
+		return false
+	}
+	s.set.Add(v)
+	return true
+}
+
+// Remove removes the value from the set.
+func (s *Set[V]) Remove(v V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Remove(v)
+}
+
+// RemoveIf removes every value for which f returns true and returns the number removed.
+//
+// f is called with the lock held and must not use the set.
+func (s *Set[V]) RemoveIf(f func(V) bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed := 0
+	for _, v := range slices.Clone(s.set.Values()) {
+		if f(v) {
+			s.set.Remove(v)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Clear removes all values from the set.
+func (s *Set[V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Clear()
+}
+
+// Contains returns true if the value is present in the set.
+func (s *Set[V]) Contains(v V) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(v)
+}
+
+// Count returns the number of values in the set.
+func (s *Set[V]) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Count()
+}
+
+// IsEmpty returns true if the set is empty.
+func (s *Set[V]) IsEmpty() bool {
+	return s.Count() == 0
+}
+
+// String returns the string representation of the set.
+func (s *Set[V]) String() string {
+	return fmt.Sprintf("ConcurrentSet[%v]", s.Values())
+}
+
+// Values returns a copy of the values in the set.
+//
+// Takes the write lock, since sets such as hashset.Set cache the slice
+// returned by Values and so aren't safe for concurrent calls to it.
+func (s *Set[V]) Values() []V {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.set.Values())
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over a snapshot of the set.
+//
+// The enumerator is unaffected by later changes to the set.
+func (s *Set[V]) GetEnumerator() enumerator.IEnumerator[V] {
+	return enumerator.GetSliceEnumerable(s.Values()).GetEnumerator()
+}
+
+// Equals tests if two sets are equal.
+func (s *Set[V]) Equals(other set.ISet[V]) bool {
+	o := snapshot(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Equals(o)
+}
+
+// Union returns a new concurrent set containing the values in either set.
+func (s *Set[V]) Union(other set.ISet[V]) set.ISet[V] {
+	o := snapshot(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewSet(s.set.Union(o))
+}
+
+// Intersection returns a new concurrent set containing the values in both sets.
+func (s *Set[V]) Intersection(other set.ISet[V]) set.ISet[V] {
+	o := snapshot(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewSet(s.set.Intersection(o))
+}
+
+// Complement returns a new concurrent set containing the values of this set which are not in other.
+func (s *Set[V]) Complement(other set.ISet[V]) set.ISet[V] {
+	o := snapshot(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewSet(s.set.Complement(o))
+}
+
+// RelativeComplement returns a new concurrent set containing the values of other which are not in this set.
+func (s *Set[V]) RelativeComplement(other set.ISet[V]) set.ISet[V] {
+	o := snapshot(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewSet(s.set.RelativeComplement(o))
+}
+
+// SymmetricDifference returns a new concurrent set containing the values in exactly one of the sets.
+func (s *Set[V]) SymmetricDifference(other set.ISet[V]) set.ISet[V] {
+	o := snapshot(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewSet(s.set.SymmetricDifference(o))
+}
+
+// SubsetOf returns true if every value of this set is in other.
+func (s *Set[V]) SubsetOf(other set.ISet[V]) bool {
+	o := snapshot(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.SubsetOf(o)
+}
+
+// SupersetOf returns true if every value of other is in this set.
+func (s *Set[V]) SupersetOf(other set.ISet[V]) bool {
+	o := snapshot(other)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.SupersetOf(o)
+}
+
+// snapshot copies other before a lock is taken, so two sets operating on each
+// other never hold both locks at once.
+func snapshot[V comparable](other set.ISet[V]) set.ISet[V] {
+	return hashset.NewFromSlice(other.Values())
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package concurrent_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/glasket/datastructures/collection/concurrent"
+	"github.com/glasket/datastructures/collection/set/hashset"
+	"github.com/glasket/datastructures/collection/set/treeset"
+)
+
+const WRITERS int = 8
+
+func TestSetAddIfAbsent(t *testing.T) {
+	s := NewSet[int](hashset.New[int](0))
+	added := atomic.Int64{}
+	var wg sync.WaitGroup
+	for w := 0; w < WRITERS; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if s.AddIfAbsent(i) {
+					added.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if added.Load() != 100 || s.Count() != 100 {
+		t.Errorf("Expected AddIfAbsent to add each value once, got %d adds and %d values", added.Load(), s.Count())
+	}
+
+	removed := s.RemoveIf(func(i int) bool { return i%2 == 0 })
+	if removed != 50 || s.Contains(0) || !s.Contains(1) {
+		t.Errorf("Expected RemoveIf to remove the 50 even values, removed %d", removed)
+	}
+}
+
+func TestSetEnumeratorSnapshot(t *testing.T) {
+	s := NewSet[int](hashset.NewFromSlice([]int{1, 2, 3}))
+	e := s.GetEnumerator()
+	s.Add(4)
+	s.Remove(1)
+	count := 0
+	for e.Next() {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Expected the enumerator to see the 3 values at creation, got %d", count)
+	}
+}
+
+func TestSetConcurrentReaders(t *testing.T) {
+	s := NewSet[int](hashset.NewFromSlice([]int{1, 2, 3}))
+	var wg sync.WaitGroup
+	for w := 0; w < WRITERS; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				// Each read rebuilds the cached values of the hashset after a write
+				s.Values()
+				_ = s.String()
+				s.GetEnumerator()
+				s.Union(s)
+				if w == 0 {
+					s.Add(i)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if len(s.Values()) != s.Count() {
+		t.Errorf("Expected Values to return %d values, got %d", s.Count(), len(s.Values()))
+	}
+}
+
+func TestSetOperationsDoNotDeadlock(t *testing.T) {
+	a := NewSet[int](treeset.NewOrdered[int]())
+	b := NewSet[int](hashset.New[int](0))
+	for i := 0; i < 10; i++ {
+		a.Add(i)
+		b.Add(i + 5)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var wg sync.WaitGroup
+		for w := 0; w < WRITERS; w++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					a.Union(b)
+					a.Add(i)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					b.Intersection(a)
+					b.Add(i)
+				}
+			}()
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected set operations on each other to finish")
+	}
+
+	if !a.Union(a).Equals(a) || !a.SubsetOf(a.Union(b)) || !a.Union(b).SupersetOf(b) {
+		t.Error("Set operations returned an incorrect result")
+	}
+	if a.SymmetricDifference(a).Count() != 0 {
+		t.Error("Expected the symmetric difference of a set with itself to be empty")
+	}
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package concurrentmap
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ enumerator.IEnumerable[Entry[int, int]] = (*Map[int, int])(nil)
+
+// Entry is a key/value pair of a Map.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// shard is one lock stripe of a Map.
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+	// count is len(m), readable without taking mu
+	count atomic.Int64
+	// Keeps neighbouring shards' locks off the same cache line
+	_ [24]byte
+}
+
+// Map is a hash map safe for concurrent use, split into shards which are
+// each guarded by their own sync.RWMutex.
+//
+// Keys are assigned to shards with hash/maphash, so writers to different
+// shards never contend.
+type Map[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []shard[K, V]
+	mask   uint64
+}
+
+// New creates an empty map with the given number of shards, rounded up to a power of two.
+//
+// If shards <= 0, four shards per GOMAXPROCS are used.
+func New[K comparable, V any](shards int) *Map[K, V] {
+	if shards <= 0 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	m := &Map[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]shard[K, V], n),
+		mask:   uint64(n - 1),
+	}
+	for i := range m.shards {
+		m.shards[i].m = make(map[K]V)
+	}
+	return m
+}
+
+// Load returns the value assigned to the key and whether it was present.
+func (m *Map[K, V]) Load(key K) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[key]
+	return v, ok
+}
+
+// Contains returns true if the key is present in the map.
+func (m *Map[K, V]) Contains(key K) bool {
+	_, ok := m.Load(key)
+	return ok
+}
+
+// Store assigns the value to the key.
+func (m *Map[K, V]) Store(key K, value V) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.m[key]; !ok {
+		s.count.Add(1)
+	}
+	s.m[key] = value
+}
+
+// LoadOrStore returns the value assigned to the key if it is present.
+// Otherwise it assigns the given value and returns it.
+//
+// loaded is true if the value was already present.
+func (m *Map[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[key]; ok {
+		return v, true
+	}
+	s.m[key] = value
+	s.count.Add(1)
+	return value, false
+}
+
+// LoadAndDelete removes the key and returns its value and whether it was present.
+func (m *Map[K, V]) LoadAndDelete(key K) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.m[key]
+	if ok {
+		delete(s.m, key)
+		s.count.Add(-1)
+	}
+	return v, ok
+}
+
+// Delete removes the key.
+func (m *Map[K, V]) Delete(key K) {
+	m.LoadAndDelete(key)
+}
+
+// Compute atomically replaces the value of the key with the result of f.
+//
+// f is called with the current value and whether it was present. If f returns
+// keep as false the key is removed, otherwise it is assigned the returned
+// value. Compute returns the new value and whether the key is now present.
+//
+// f is called with the key's shard locked and must not use the map.
+func (m *Map[K, V]) Compute(key K, f func(old V, loaded bool) (value V, keep bool)) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, loaded := s.m[key]
+	value, keep := f(old, loaded)
+	switch {
+	case keep:
+		if !loaded {
+			s.count.Add(1)
+		}
+		s.m[key] = value
+		return value, true
+	case loaded:
+		delete(s.m, key)
+		s.count.Add(-1)
+	}
+	return *new(V), false
+}
+
+// Range calls f for each key and value in the map until f returns false.
+//
+// Each shard is copied under its lock before f is called on its entries, so
+// the entries of a shard are a consistent snapshot, and f may use the map.
+// Changes to shards which have not been reached yet may or may not be seen.
+func (m *Map[K, V]) Range(f func(K, V) bool) {
+	for i := range m.shards {
+		for _, e := range m.snapshot(i) {
+			if !f(e.Key, e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the keys and values of the map, see Range.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return m.Range
+}
+
+// Len returns the number of keys in the map.
+//
+// Each shard keeps its own count, so writers never contend on the size.
+func (m *Map[K, V]) Len() int {
+	n := int64(0)
+	for i := range m.shards {
+		n += m.shards[i].count.Load()
+	}
+	return int(n)
+}
+
+// Count returns the number of keys in the map.
+func (m *Map[K, V]) Count() int {
+	return m.Len()
+}
+
+// IsEmpty returns true if the map is empty.
+func (m *Map[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Clear removes all keys from the map, one shard at a time.
+func (m *Map[K, V]) Clear() {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.Lock()
+		s.m = make(map[K]V)
+		s.count.Store(0)
+		s.mu.Unlock()
+	}
+}
+
+// String returns the string representation of the map.
+func (m *Map[K, V]) String() string {
+	return fmt.Sprintf("ConcurrentMap%v", m.Values())
+}
+
+// Values returns a slice of the entries in the map, in no particular order.
+//
+// Each shard is copied consistently, see Range.
+func (m *Map[K, V]) Values() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, m.Len())
+	for i := range m.shards {
+		entries = append(entries, m.snapshot(i)...)
+	}
+	return entries
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over a snapshot of the entries.
+func (m *Map[K, V]) GetEnumerator() enumerator.IEnumerator[Entry[K, V]] {
+	return enumerator.GetSliceEnumerable(m.Values()).GetEnumerator()
+}
+
+func (m *Map[K, V]) shardFor(key K) *shard[K, V] {
+	return &m.shards[maphash.Comparable(m.seed, key)&m.mask]
+}
+
+func (m *Map[K, V]) snapshot(i int) []Entry[K, V] {
+	s := &m.shards[i]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := make([]Entry[K, V], 0, len(s.m))
+	for k, v := range s.m {
+		entries = append(entries, Entry[K, V]{k, v})
+	}
+	return entries
+}
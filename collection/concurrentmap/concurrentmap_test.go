@@ -0,0 +1,171 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package concurrentmap_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	. "github.com/glasket/datastructures/collection/concurrentmap"
+)
+
+const WRITERS int = 8
+
+func TestNewMap(t *testing.T) {
+	m := New[string, int](0)
+	if m.Len() != 0 || !m.IsEmpty() {
+		t.Fatalf("Expected New to return an empty map, got %d keys", m.Len())
+	}
+	if _, ok := m.Load("a"); ok {
+		t.Error("Expected Load on an empty map to report false")
+	}
+}
+
+func TestMapLoadStore(t *testing.T) {
+	m := New[string, int](3)
+	m.Store("a", 1)
+	m.Store("b", 2)
+	m.Store("a", 3)
+	if v, ok := m.Load("a"); !ok || v != 3 {
+		t.Errorf("Expected Load to return 3, got %d", v)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Expected Len to be 2, got %d", m.Len())
+	}
+
+	if v, loaded := m.LoadOrStore("b", 10); !loaded || v != 2 {
+		t.Errorf("Expected LoadOrStore to load 2, got %d", v)
+	}
+	if v, loaded := m.LoadOrStore("c", 10); loaded || v != 10 {
+		t.Errorf("Expected LoadOrStore to store 10, got %d", v)
+	}
+
+	if v, ok := m.LoadAndDelete("a"); !ok || v != 3 {
+		t.Errorf("Expected LoadAndDelete to return 3, got %d", v)
+	}
+	if _, ok := m.LoadAndDelete("a"); ok {
+		t.Error("Expected LoadAndDelete of a missing key to report false")
+	}
+	m.Delete("b")
+	if m.Len() != 1 || m.Contains("b") {
+		t.Errorf("Expected only c to remain, got %v", m)
+	}
+
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Errorf("Expected Clear to empty the map, got %v", m)
+	}
+}
+
+func TestMapCompute(t *testing.T) {
+	m := New[string, int](0)
+	increment := func(old int, loaded bool) (int, bool) {
+		return old + 1, true
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < WRITERS; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Compute("hits", increment)
+			}
+		}()
+	}
+	wg.Wait()
+	if v, _ := m.Load("hits"); v != WRITERS*1000 {
+		t.Errorf("Expected Compute to count %d hits, got %d", WRITERS*1000, v)
+	}
+
+	if _, ok := m.Compute("hits", func(int, bool) (int, bool) { return 0, false }); ok || m.Len() != 0 {
+		t.Errorf("Expected Compute returning keep false to remove the key, got %d keys", m.Len())
+	}
+	if _, ok := m.Compute("missing", func(int, bool) (int, bool) { return 0, false }); ok || m.Len() != 0 {
+		t.Errorf("Expected Compute on a missing key returning keep false to do nothing, got %d keys", m.Len())
+	}
+}
+
+func TestMapConcurrentWriters(t *testing.T) {
+	m := New[int, int](4)
+	var wg sync.WaitGroup
+	for w := 0; w < WRITERS; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Store(w*1000+i, i)
+				if i%2 == 0 {
+					m.Delete(w*1000 + i)
+				}
+				m.Load(i)
+			}
+		}(w)
+	}
+	// Readers running alongside the writers
+	for r := 0; r < 2; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				m.Range(func(k, v int) bool {
+					return true
+				})
+				_ = m.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if m.Len() != WRITERS*500 || len(m.Values()) != WRITERS*500 {
+		t.Errorf("Expected %d keys, got %d", WRITERS*500, m.Len())
+	}
+}
+
+func TestMapRange(t *testing.T) {
+	m := New[int, int](4)
+	for i := 0; i < 100; i++ {
+		m.Store(i, i*i)
+	}
+
+	keys := make([]int, 0)
+	for k, v := range m.All() {
+		if v != k*k {
+			t.Errorf("Expected %d to map to %d, got %d", k, k*k, v)
+		}
+		// Range doesn't hold a lock while calling back
+		m.Delete(k)
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	if len(keys) != 100 || keys[0] != 0 || keys[99] != 99 || !m.IsEmpty() {
+		t.Errorf("Expected Range to visit every key once, got %d keys", len(keys))
+	}
+
+	m.Store(1, 1)
+	m.Store(2, 2)
+	count := 0
+	m.Range(func(int, int) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Errorf("Expected Range to stop when f returns false, got %d calls", count)
+	}
+
+	e := m.GetEnumerator()
+	entries := 0
+	for e.Next() {
+		entries++
+	}
+	if entries != 2 {
+		t.Errorf("Expected the enumerator to yield 2 entries, got %d", entries)
+	}
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package cow provides copy-on-write collections for read-mostly workloads.
+//
+// Reads load an immutable snapshot without locking, while every write copies
+// the snapshot, modifies the copy and publishes it under a mutex. Writes cost
+// O(n), so these collections suit data which is read far more often than it
+// is written.
+package cow
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/glasket/datastructures/collection/list"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ list.IList[int] = (*List[int])(nil)
+
+// List is a copy-on-write list which is safe for concurrent use.
+type List[V comparable] struct {
+	mu       sync.Mutex
+	elements atomic.Pointer[[]V]
+}
+
+// NewList creates an empty list.
+func NewList[V comparable]() *List[V] {
+	l := &List[V]{}
+	l.elements.Store(&[]V{})
+	return l
+}
+
+// NewListFromSlice creates a list containing a copy of the slice.
+func NewListFromSlice[V comparable](s []V) *List[V] {
+	l := &List[V]{}
+	c := slices.Clone(s)
+	l.elements.Store(&c)
+	return l
+}
+
+func (l *List[V]) snapshot() []V {
+	return *l.elements.Load()
+}
+
+// write publishes the slice returned by f as the new snapshot. The snapshot is
+// left unchanged if f returns an error or a nil slice.
+//
+// f is called with the write lock held and must not modify its argument.
+func (l *List[V]) write(f func(old []V) ([]V, error)) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	next, err := f(l.snapshot())
+	if err != nil || next == nil {
+		return err
+	}
+	l.elements.Store(&next)
+	return nil
+}
+
+// Add appends the value to the list.
+func (l *List[V]) Add(v V) {
+	l.write(func(old []V) ([]V, error) {
+		return append(slices.Clip(old), v), nil
+	})
+}
+
+// AddIfAbsent appends the value to the list and returns true if it was not already present.
+func (l *List[V]) AddIfAbsent(v V) bool {
+	added := false
+	l.write(func(old []V) ([]V, error) {
+		if slices.Contains(old, v) {
+			return nil, nil
+		}
+		added = true
+		return append(slices.Clip(old), v), nil
+	})
+	return added
+}
+
+// Remove removes the first occurrence of the value from the list.
+func (l *List[V]) Remove(v V) {
+	l.write(func(old []V) ([]V, error) {
+		i := slices.Index(old, v)
+		if i < 0 {
+			return nil, nil
+		}
+		return slices.Delete(slices.Clone(old), i, i+1), nil
+	})
+}
+
+// RemoveIf removes every value for which f returns true and returns the number removed.
+//
+// f is called with the write lock held and must not modify the list.
+func (l *List[V]) RemoveIf(f func(V) bool) int {
+	removed := 0
+	l.write(func(old []V) ([]V, error) {
+		next := slices.DeleteFunc(slices.Clone(old), f)
+		removed = len(old) - len(next)
+		if removed == 0 {
+			return nil, nil
+		}
+		return next, nil
+	})
+	return removed
+}
+
+// Clear removes all values from the list.
+func (l *List[V]) Clear() {
+	l.write(func([]V) ([]V, error) {
+		return []V{}, nil
+	})
+}
+
+// Get returns the value at the index.
+func (l *List[V]) Get(i int) (V, error) {
+	s := l.snapshot()
+	if err := checkBounds(s, i); err != nil {
+		return *new(V), err
+	}
+	return s[i], nil
+}
+
+// Set replaces the value at the index.
+func (l *List[V]) Set(i int, v V) error {
+	return l.write(func(old []V) ([]V, error) {
+		if err := checkBounds(old, i); err != nil {
+			return nil, err
+		}
+		next := slices.Clone(old)
+		next[i] = v
+		return next, nil
+	})
+}
+
+// InsertAt inserts the value at the index. An index equal to Count appends the value.
+func (l *List[V]) InsertAt(i int, v V) error {
+	return l.write(func(old []V) ([]V, error) {
+		if i != len(old) {
+			if err := checkBounds(old, i); err != nil {
+				return nil, err
+			}
+		}
+		return slices.Insert(slices.Clone(old), i, v), nil
+	})
+}
+
+// RemoveAt removes the value at the index.
+func (l *List[V]) RemoveAt(i int) error {
+	return l.write(func(old []V) ([]V, error) {
+		if err := checkBounds(old, i); err != nil {
+			return nil, err
+		}
+		return slices.Delete(slices.Clone(old), i, i+1), nil
+	})
+}
+
+// IndexOf returns the index of the first occurrence of the value.
+func (l *List[V]) IndexOf(v V) (int, error) {
+	if i := slices.Index(l.snapshot(), v); i >= 0 {
+		return i, nil
+	}
+	return -1, fmt.Errorf("value %v not found", v)
+}
+
+// Contains returns true if the value is present in the list.
+func (l *List[V]) Contains(v V) bool {
+	return slices.Contains(l.snapshot(), v)
+}
+
+// Count returns the number of values in the list.
+func (l *List[V]) Count() int {
+	return len(l.snapshot())
+}
+
+// IsEmpty returns true if the list is empty.
+func (l *List[V]) IsEmpty() bool {
+	return l.Count() == 0
+}
+
+// String returns the string representation of the list.
+func (l *List[V]) String() string {
+	return fmt.Sprintf("CowList%v", l.snapshot())
+}
+
+// Values returns a copy of the values in the list.
+func (l *List[V]) Values() []V {
+	return slices.Clone(l.snapshot())
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over the current snapshot of the list.
+//
+// The enumerator is unaffected by later changes to the list.
+func (l *List[V]) GetEnumerator() enumerator.IEnumerator[V] {
+	return enumerator.GetSliceEnumerable(l.snapshot()).GetEnumerator()
+}
+
+// All returns an iterator over the indices and values of the current snapshot of the list.
+func (l *List[V]) All() iter.Seq2[int, V] {
+	return slices.All(l.snapshot())
+}
+
+func checkBounds[V any](s []V, i int) error {
+	if i < 0 || i >= len(s) {
+		return fmt.Errorf("index %d out of bounds", i)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cow_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	. "github.com/glasket/datastructures/collection/cow"
+)
+
+const (
+	READERS int = 8
+	WRITERS int = 2
+)
+
+func TestListIndexed(t *testing.T) {
+	source := []int{1, 2, 3}
+	l := NewListFromSlice(source)
+	source[0] = 100
+	if v, _ := l.Get(0); v != 1 {
+		t.Errorf("Expected the list to copy its source slice, got %d", v)
+	}
+
+	l.Add(4)
+	if err := l.InsertAt(0, 0); err != nil {
+		t.Errorf("Expected InsertAt(0) to succeed, got %v", err)
+	}
+	if err := l.InsertAt(l.Count(), 5); err != nil {
+		t.Errorf("Expected InsertAt(Count) to append, got %v", err)
+	}
+	if err := l.InsertAt(10, 5); err == nil {
+		t.Error("Expected InsertAt out of bounds to fail")
+	}
+	l.Set(1, 10)
+	l.RemoveAt(2)
+	l.Remove(4)
+	if !reflect.DeepEqual(l.Values(), []int{0, 10, 3, 5}) {
+		t.Errorf("Expected list to be [0 10 3 5], got %v", l.Values())
+	}
+	if i, err := l.IndexOf(3); err != nil || i != 2 {
+		t.Errorf("Expected IndexOf(3) to be 2, got %d", i)
+	}
+	if _, err := l.IndexOf(4); err == nil {
+		t.Error("Expected IndexOf of a removed value to fail")
+	}
+	if _, err := l.Get(-1); err == nil {
+		t.Error("Expected Get(-1) to fail")
+	}
+	if l.String() != "CowList[0 10 3 5]" {
+		t.Errorf("Expected String to be CowList[0 10 3 5], got %s", l.String())
+	}
+
+	if !l.AddIfAbsent(7) || l.AddIfAbsent(0) {
+		t.Error("Expected AddIfAbsent to add only absent values")
+	}
+	if removed := l.RemoveIf(func(i int) bool { return i > 4 }); removed != 3 {
+		t.Errorf("Expected RemoveIf to remove [10 5 7], removed %d", removed)
+	}
+	l.Clear()
+	if !l.IsEmpty() {
+		t.Error("Expected list to be empty")
+	}
+}
+
+func TestListSnapshots(t *testing.T) {
+	l := NewListFromSlice([]int{1, 2, 3})
+	e := l.GetEnumerator()
+	values := l.Values()
+	values[0] = 100
+
+	l.Set(0, 10)
+	l.Add(4)
+	l.RemoveAt(1)
+	got := make([]int, 0)
+	for e.Next() {
+		got = append(got, e.Current())
+	}
+	if !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("Expected the enumerator to see the snapshot [1 2 3], got %v", got)
+	}
+
+	got = got[:0]
+	for _, v := range l.All() {
+		l.Add(v)
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []int{10, 3, 4}) || l.Count() != 6 {
+		t.Errorf("Expected All to iterate the snapshot [10 3 4], got %v", got)
+	}
+}
+
+func TestListConcurrentReaders(t *testing.T) {
+	l := NewList[int]()
+	var wg sync.WaitGroup
+	for w := 0; w < WRITERS; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				l.Add(w*100 + i)
+			}
+		}(w)
+	}
+	for r := 0; r < READERS; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				// Every snapshot must be internally consistent
+				e := l.GetEnumerator()
+				n := 0
+				for e.Next() {
+					n++
+				}
+				if n > WRITERS*100 {
+					t.Errorf("Expected at most %d values, got %d", WRITERS*100, n)
+				}
+				l.Contains(i)
+			}
+		}()
+	}
+	wg.Wait()
+	if l.Count() != WRITERS*100 {
+		t.Errorf("Expected %d values, got %d", WRITERS*100, l.Count())
+	}
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cow
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/glasket/datastructures/collection/set"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ set.ISet[int] = (*Set[int])(nil)
+
+// setSnapshot is an immutable version of a Set. values holds the keys of set
+// so enumerating a snapshot never allocates.
+type setSnapshot[V comparable] struct {
+	set    map[V]struct{}
+	values []V
+}
+
+func newSetSnapshot[V comparable](m map[V]struct{}) *setSnapshot[V] {
+	return &setSnapshot[V]{
+		set:    m,
+		values: slices.AppendSeq(make([]V, 0, len(m)), maps.Keys(m)),
+	}
+}
+
+// Set is a copy-on-write set which is safe for concurrent use.
+type Set[V comparable] struct {
+	mu   sync.Mutex
+	snap atomic.Pointer[setSnapshot[V]]
+}
+
+// NewSet creates an empty set.
+func NewSet[V comparable]() *Set[V] {
+	return newSet(map[V]struct{}{})
+}
+
+// NewSetFromSlice creates a set containing the values of the slice.
+func NewSetFromSlice[V comparable](s []V) *Set[V] {
+	m := make(map[V]struct{}, len(s))
+	for _, v := range s {
+		m[v] = struct{}{}
+	}
+	return newSet(m)
+}
+
+func newSet[V comparable](m map[V]struct{}) *Set[V] {
+	s := &Set[V]{}
+	s.snap.Store(newSetSnapshot(m))
+	return s
+}
+
+func (s *Set[V]) snapshot() *setSnapshot[V] {
+	return s.snap.Load()
+}
+
+// write publishes the map returned by f as the new snapshot. The snapshot is
+// left unchanged if f returns nil.
+//
+// f is called with the write lock held and must not modify its argument.
+func (s *Set[V]) write(f func(old map[V]struct{}) map[V]struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if next := f(s.snapshot().set); next != nil {
+		s.snap.Store(newSetSnapshot(next))
+	}
+}
+
+// Add adds the value to the set.
+func (s *Set[V]) Add(v V) {
+	s.AddIfAbsent(v)
+}
+
+// AddIfAbsent adds the value to the set and returns true if it was not already present.
+func (s *Set[V]) AddIfAbsent(v V) bool {
+	added := false
+	s.write(func(old map[V]struct{}) map[V]struct{} {
+		if _, ok := old[v]; ok {
+			return nil
+		}
+		added = true
+		next := maps.Clone(old)
+		next[v] = struct{}{}
+		return next
+	})
+	return added
+}
+
+// Remove removes the value from the set.
+func (s *Set[V]) Remove(v V) {
+	s.write(func(old map[V]struct{}) map[V]struct{} {
+		if _, ok := old[v]; !ok {
+			return nil
+		}
+		next := maps.Clone(old)
+		delete(next, v)
+		return next
+	})
+}
+
+// RemoveIf removes every value for which f returns true and returns the number removed.
+//
+// f is called with the write lock held and must not modify the set.
+func (s *Set[V]) RemoveIf(f func(V) bool) int {
+	removed := 0
+	s.write(func(old map[V]struct{}) map[V]struct{} {
+		next := maps.Clone(old)
+		maps.DeleteFunc(next, func(v V, _ struct{}) bool { return f(v) })
+		removed = len(old) - len(next)
+		if removed == 0 {
+			return nil
+		}
+		return next
+	})
+	return removed
+}
+
+// Clear removes all values from the set.
+func (s *Set[V]) Clear() {
+	s.write(func(map[V]struct{}) map[V]struct{} {
+		return map[V]struct{}{}
+	})
+}
+
+// Contains returns true if the value is present in the set.
+func (s *Set[V]) Contains(v V) bool {
+	_, ok := s.snapshot().set[v]
+	return ok
+}
+
+// Count returns the number of values in the set.
+func (s *Set[V]) Count() int {
+	return len(s.snapshot().set)
+}
+
+// IsEmpty returns true if the set is empty.
+func (s *Set[V]) IsEmpty() bool {
+	return s.Count() == 0
+}
+
+// String returns the string representation of the set.
+func (s *Set[V]) String() string {
+	return fmt.Sprintf("CowSet[%v]", s.snapshot().values)
+}
+
+// Values returns a copy of the values in the set.
+func (s *Set[V]) Values() []V {
+	return slices.Clone(s.snapshot().values)
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over the current snapshot of the set.
+//
+// The enumerator is unaffected by later changes to the set.
+func (s *Set[V]) GetEnumerator() enumerator.IEnumerator[V] {
+	return enumerator.GetSliceEnumerable(s.snapshot().values).GetEnumerator()
+}
+
+// All returns an iterator over the values of the current snapshot of the set.
+func (s *Set[V]) All() iter.Seq[V] {
+	return slices.Values(s.snapshot().values)
+}
+
+// Equals tests if two sets are equal.
+func (s *Set[V]) Equals(other set.ISet[V]) bool {
+	snap := s.snapshot()
+	return len(snap.set) == other.Count() && containsAll(other, snap.values)
+}
+
+// Union returns a new copy-on-write set containing the values in either set.
+func (s *Set[V]) Union(other set.ISet[V]) set.ISet[V] {
+	union := maps.Clone(s.snapshot().set)
+	for _, v := range other.Values() {
+		union[v] = struct{}{}
+	}
+	return newSet(union)
+}
+
+// Intersection returns a new copy-on-write set containing the values in both sets.
+func (s *Set[V]) Intersection(other set.ISet[V]) set.ISet[V] {
+	return s.filter(other.Contains)
+}
+
+// Complement returns a new copy-on-write set containing the values of this set which are not in other.
+func (s *Set[V]) Complement(other set.ISet[V]) set.ISet[V] {
+	return s.filter(func(v V) bool { return !other.Contains(v) })
+}
+
+// RelativeComplement returns a new set containing the values of other which are not in this set.
+func (s *Set[V]) RelativeComplement(other set.ISet[V]) set.ISet[V] {
+	return other.Complement(s)
+}
+
+// SymmetricDifference returns a new copy-on-write set containing the values in exactly one of the sets.
+func (s *Set[V]) SymmetricDifference(other set.ISet[V]) set.ISet[V] {
+	snap := s.snapshot()
+	diff := make(map[V]struct{})
+	for _, v := range snap.values {
+		if !other.Contains(v) {
+			diff[v] = struct{}{}
+		}
+	}
+	for _, v := range other.Values() {
+		if _, ok := snap.set[v]; !ok {
+			diff[v] = struct{}{}
+		}
+	}
+	return newSet(diff)
+}
+
+// SubsetOf returns true if every value of this set is in other.
+func (s *Set[V]) SubsetOf(other set.ISet[V]) bool {
+	snap := s.snapshot()
+	return len(snap.set) <= other.Count() && containsAll(other, snap.values)
+}
+
+// SupersetOf returns true if every value of other is in this set.
+func (s *Set[V]) SupersetOf(other set.ISet[V]) bool {
+	snap := s.snapshot()
+	for _, v := range other.Values() {
+		if _, ok := snap.set[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// filter returns a new copy-on-write set containing the values of the current snapshot for which f returns true.
+func (s *Set[V]) filter(f func(V) bool) *Set[V] {
+	m := make(map[V]struct{})
+	for _, v := range s.snapshot().values {
+		if f(v) {
+			m[v] = struct{}{}
+		}
+	}
+	return newSet(m)
+}
+
+func containsAll[V comparable](s set.ISet[V], values []V) bool {
+	for _, v := range values {
+		if !s.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cow_test
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/glasket/datastructures/collection/cow"
+	"github.com/glasket/datastructures/collection/set/hashset"
+)
+
+func TestSetAddIfAbsent(t *testing.T) {
+	s := NewSet[int]()
+	added := atomic.Int64{}
+	var wg sync.WaitGroup
+	for w := 0; w < WRITERS; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if s.AddIfAbsent(i) {
+					added.Add(1)
+				}
+			}
+		}()
+	}
+	for r := 0; r < READERS; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if len(s.Values()) > 100 {
+					t.Error("Expected no more than 100 values")
+				}
+				s.Contains(i)
+			}
+		}()
+	}
+	wg.Wait()
+	if added.Load() != 100 || s.Count() != 100 {
+		t.Errorf("Expected AddIfAbsent to add each value once, got %d adds and %d values", added.Load(), s.Count())
+	}
+
+	removed := s.RemoveIf(func(i int) bool { return i%2 == 0 })
+	if removed != 50 || s.Contains(0) || !s.Contains(1) {
+		t.Errorf("Expected RemoveIf to remove the 50 even values, removed %d", removed)
+	}
+	s.Remove(1)
+	s.Add(3)
+	if s.Count() != 49 {
+		t.Errorf("Expected 49 values, got %d", s.Count())
+	}
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Error("Expected set to be empty")
+	}
+}
+
+func TestSetEnumeratorSnapshot(t *testing.T) {
+	s := NewSetFromSlice([]int{1, 2, 3, 3})
+	e := s.GetEnumerator()
+	s.Add(4)
+	s.Remove(1)
+	values := make([]int, 0)
+	for e.Next() {
+		values = append(values, e.Current())
+	}
+	slices.Sort(values)
+	if !slices.Equal(values, []int{1, 2, 3}) {
+		t.Errorf("Expected the enumerator to see the snapshot [1 2 3], got %v", values)
+	}
+
+	count := 0
+	for v := range s.All() {
+		s.Remove(v)
+		count++
+	}
+	if count != 3 || !s.IsEmpty() {
+		t.Errorf("Expected All to iterate the 3 values of its snapshot, got %d", count)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a := NewSetFromSlice([]int{1, 2, 3, 4})
+	b := hashset.NewFromSlice([]int{3, 4, 5})
+
+	sorted := func(values []int) []int {
+		slices.Sort(values)
+		return values
+	}
+	for _, c := range []struct {
+		name     string
+		got      []int
+		expected []int
+	}{
+		{"Union", a.Union(b).Values(), []int{1, 2, 3, 4, 5}},
+		{"Intersection", a.Intersection(b).Values(), []int{3, 4}},
+		{"Complement", a.Complement(b).Values(), []int{1, 2}},
+		{"RelativeComplement", a.RelativeComplement(b).Values(), []int{5}},
+		{"SymmetricDifference", a.SymmetricDifference(b).Values(), []int{1, 2, 5}},
+	} {
+		if got := sorted(c.got); !slices.Equal(got, c.expected) {
+			t.Errorf("Expected %s to be %v, got %v", c.name, c.expected, got)
+		}
+	}
+
+	if !a.Equals(hashset.NewFromSlice([]int{4, 3, 2, 1})) || a.Equals(b) {
+		t.Error("Expected Equals to compare values")
+	}
+	if !a.Intersection(b).SubsetOf(a) || a.SubsetOf(b) {
+		t.Error("Expected SubsetOf to be true only for a subset")
+	}
+	if !a.SupersetOf(a.Intersection(b)) || a.SupersetOf(b) {
+		t.Error("Expected SupersetOf to be true only for a superset")
+	}
+}
@@ -0,0 +1,290 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package deque
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/glasket/datastructures/collection"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ collection.ICollection[int] = (*Deque[int])(nil)
+
+const minCapacity = 8
+
+// Deque is a double-ended queue backed by a growable ring buffer.
+//
+// The buffer length is always a power of two, so indices wrap with a mask.
+// The buffer doubles when full and halves when a quarter full, but never
+// shrinks below the capacity the deque was created with.
+type Deque[V comparable] struct {
+	buf     []V
+	head    int
+	count   int
+	minSize int
+}
+
+// New creates a deque able to hold size values before growing.
+//
+// size is rounded up to the next power of two.
+func New[V comparable](size int) *Deque[V] {
+	c := nextPow2(size)
+	return &Deque[V]{
+		buf:     make([]V, c),
+		head:    0,
+		count:   0,
+		minSize: c,
+	}
+}
+
+// NewFromSlice creates a deque containing the values of the slice, front to back.
+func NewFromSlice[V comparable](s []V) *Deque[V] {
+	d := New[V](len(s))
+	for _, v := range s {
+		d.PushBack(v)
+	}
+	return d
+}
+
+// PushFront adds the value to the front of the deque.
+func (d *Deque[V]) PushFront(v V) {
+	d.growIfFull()
+	d.head = (d.head - 1) & d.mask()
+	d.buf[d.head] = v
+	d.count += 1
+}
+
+// PushBack adds the value to the back of the deque.
+func (d *Deque[V]) PushBack(v V) {
+	d.growIfFull()
+	d.buf[(d.head+d.count)&d.mask()] = v
+	d.count += 1
+}
+
+// PopFront removes and returns the value at the front of the deque.
+//
+// Returns an error if the deque is empty.
+func (d *Deque[V]) PopFront() (V, error) {
+	if d.count == 0 {
+		return *new(V), fmt.Errorf("deque is empty")
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = *new(V)
+	d.head = (d.head + 1) & d.mask()
+	d.count -= 1
+	d.shrinkIfSparse()
+	return v, nil
+}
+
+// PopBack removes and returns the value at the back of the deque.
+//
+// Returns an error if the deque is empty.
+func (d *Deque[V]) PopBack() (V, error) {
+	if d.count == 0 {
+		return *new(V), fmt.Errorf("deque is empty")
+	}
+	i := (d.head + d.count - 1) & d.mask()
+	v := d.buf[i]
+	d.buf[i] = *new(V)
+	d.count -= 1
+	d.shrinkIfSparse()
+	return v, nil
+}
+
+// PeekFront returns the value at the front of the deque without removing it.
+//
+// Returns an error if the deque is empty.
+func (d *Deque[V]) PeekFront() (V, error) {
+	if d.count == 0 {
+		return *new(V), fmt.Errorf("deque is empty")
+	}
+	return d.buf[d.head], nil
+}
+
+// PeekBack returns the value at the back of the deque without removing it.
+//
+// Returns an error if the deque is empty.
+func (d *Deque[V]) PeekBack() (V, error) {
+	if d.count == 0 {
+		return *new(V), fmt.Errorf("deque is empty")
+	}
+	return d.buf[(d.head+d.count-1)&d.mask()], nil
+}
+
+// Get returns the value at index i, counting from the front.
+func (d *Deque[V]) Get(i int) (V, error) {
+	if err := d.checkBounds(i); err != nil {
+		return *new(V), err
+	}
+	return d.buf[(d.head+i)&d.mask()], nil
+}
+
+// Set replaces the value at index i, counting from the front.
+func (d *Deque[V]) Set(i int, v V) error {
+	if err := d.checkBounds(i); err != nil {
+		return err
+	}
+	d.buf[(d.head+i)&d.mask()] = v
+	return nil
+}
+
+// Add adds the value to the back of the deque.
+func (d *Deque[V]) Add(v V) {
+	d.PushBack(v)
+}
+
+// Remove removes the first occurrence of the value, searching from the front.
+//
+// no-op if value is not present.
+func (d *Deque[V]) Remove(v V) {
+	i := d.indexOf(v)
+	if i < 0 {
+		return
+	}
+	// Shift whichever side of the removed value is shorter
+	if i < d.count/2 {
+		for j := i; j > 0; j-- {
+			d.buf[(d.head+j)&d.mask()] = d.buf[(d.head+j-1)&d.mask()]
+		}
+		d.buf[d.head] = *new(V)
+		d.head = (d.head + 1) & d.mask()
+	} else {
+		for j := i; j < d.count-1; j++ {
+			d.buf[(d.head+j)&d.mask()] = d.buf[(d.head+j+1)&d.mask()]
+		}
+		d.buf[(d.head+d.count-1)&d.mask()] = *new(V)
+	}
+	d.count -= 1
+	d.shrinkIfSparse()
+}
+
+// Clear removes all values from the deque and resets it to its initial capacity.
+func (d *Deque[V]) Clear() {
+	d.buf = make([]V, d.minSize)
+	d.head = 0
+	d.count = 0
+}
+
+// Contains returns true if the value is present in the deque.
+func (d *Deque[V]) Contains(v V) bool {
+	return d.indexOf(v) >= 0
+}
+
+// Count returns the number of values in the deque.
+func (d *Deque[V]) Count() int {
+	return d.count
+}
+
+// IsEmpty returns true if the deque is empty.
+func (d *Deque[V]) IsEmpty() bool {
+	return d.Count() == 0
+}
+
+// String returns the string representation of the deque.
+func (d *Deque[V]) String() string {
+	return fmt.Sprintf("Deque[%v]", d.Values())
+}
+
+// Values returns a new slice containing the values from front to back.
+func (d *Deque[V]) Values() []V {
+	values := make([]V, d.count)
+	d.copyTo(values)
+	return values
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over the deque from front to back.
+func (d *Deque[V]) GetEnumerator() enumerator.IEnumerator[V] {
+	return enumerator.GetSliceEnumerable(d.Values()).GetEnumerator()
+}
+
+// All returns an iterator over the indices and values of the deque, from front to back.
+func (d *Deque[V]) All() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		for i := 0; i < d.count; i++ {
+			if !yield(i, d.buf[(d.head+i)&d.mask()]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the indices and values of the deque, from back to front.
+func (d *Deque[V]) Backward() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		for i := d.count - 1; i >= 0; i-- {
+			// Values may have been popped during the previous yield
+			if i >= d.count {
+				continue
+			}
+			if !yield(i, d.buf[(d.head+i)&d.mask()]) {
+				return
+			}
+		}
+	}
+}
+
+func (d *Deque[V]) mask() int {
+	return len(d.buf) - 1
+}
+
+func (d *Deque[V]) indexOf(v V) int {
+	for i := 0; i < d.count; i++ {
+		if d.buf[(d.head+i)&d.mask()] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func (d *Deque[V]) growIfFull() {
+	if d.count == len(d.buf) {
+		d.resize(len(d.buf) * 2)
+	}
+}
+
+func (d *Deque[V]) shrinkIfSparse() {
+	if len(d.buf) > d.minSize && d.count <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+func (d *Deque[V]) resize(size int) {
+	buf := make([]V, size)
+	d.copyTo(buf)
+	d.buf = buf
+	d.head = 0
+}
+
+// copyTo copies the values in order into dst, which must hold at least count values.
+func (d *Deque[V]) copyTo(dst []V) {
+	end := d.head + d.count
+	if end <= len(d.buf) {
+		copy(dst, d.buf[d.head:end])
+		return
+	}
+	n := copy(dst, d.buf[d.head:])
+	copy(dst[n:], d.buf[:end-len(d.buf)])
+}
+
+func (d *Deque[V]) checkBounds(i int) error {
+	if i < 0 || i >= d.count {
+		return fmt.Errorf("index %d out of bounds", i)
+	}
+	return nil
+}
+
+func nextPow2(n int) int {
+	c := minCapacity
+	for c < n {
+		c <<= 1
+	}
+	return c
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package deque_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/glasket/datastructures/collection/deque"
+)
+
+func TestNewDeque(t *testing.T) {
+	d := New[int](0)
+	if d.Count() != 0 {
+		t.Fatalf("Expected New to return a deque of size 0, got %d", d.Count())
+	}
+	if _, err := d.PopFront(); err == nil {
+		t.Error("Expected PopFront to error on an empty deque")
+	}
+	if _, err := d.PeekBack(); err == nil {
+		t.Error("Expected PeekBack to error on an empty deque")
+	}
+}
+
+func TestDequePushPop(t *testing.T) {
+	d := New[int](0)
+	d.PushBack(2)
+	d.PushFront(1)
+	d.PushBack(3)
+	if !reflect.DeepEqual(d.Values(), []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", d.Values())
+	}
+	if v, _ := d.PeekFront(); v != 1 {
+		t.Errorf("Expected PeekFront to return 1, got %d", v)
+	}
+	if v, _ := d.PeekBack(); v != 3 {
+		t.Errorf("Expected PeekBack to return 3, got %d", v)
+	}
+	if v, _ := d.PopFront(); v != 1 {
+		t.Errorf("Expected PopFront to return 1, got %d", v)
+	}
+	if v, _ := d.PopBack(); v != 3 {
+		t.Errorf("Expected PopBack to return 3, got %d", v)
+	}
+	if d.Count() != 1 {
+		t.Errorf("Expected Count to return 1, got %d", d.Count())
+	}
+}
+
+func TestDequeGrowShrink(t *testing.T) {
+	d := New[int](0)
+	// Alternate ends so the contents wrap around the buffer
+	for i := 0; i < 1000; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+	}
+	if d.Count() != 1000 {
+		t.Fatalf("Expected Count to return 1000, got %d", d.Count())
+	}
+	for i := 999; i >= 0; i-- {
+		var v int
+		if i%2 == 0 {
+			v, _ = d.PopBack()
+		} else {
+			v, _ = d.PopFront()
+		}
+		if v != i {
+			t.Fatalf("Expected %d, got %d", i, v)
+		}
+	}
+	if !d.IsEmpty() {
+		t.Errorf("Expected deque to be empty, got %v", d)
+	}
+}
+
+func TestDequeIndexed(t *testing.T) {
+	d := New[int](0)
+	for i := 7; i >= 0; i-- {
+		d.PushFront(i)
+	}
+	for i := 0; i < 8; i++ {
+		if v, err := d.Get(i); v != i || err != nil {
+			t.Errorf("Expected Get(%d) to return %d, got %d (%v)", i, i, v, err)
+		}
+	}
+	if err := d.Set(3, 30); err != nil {
+		t.Errorf("Expected Set to not error, got %v", err)
+	}
+	if v, _ := d.Get(3); v != 30 {
+		t.Errorf("Expected Get(3) to return 30, got %d", v)
+	}
+	if _, err := d.Get(8); err == nil {
+		t.Error("Expected Get to error out of bounds")
+	}
+	if err := d.Set(-1, 0); err == nil {
+		t.Error("Expected Set to error out of bounds")
+	}
+}
+
+func TestDequeRemove(t *testing.T) {
+	d := NewFromSlice([]int{1, 2, 3, 4, 5, 6})
+	d.Remove(2)
+	d.Remove(5)
+	d.Remove(7)
+	if !reflect.DeepEqual(d.Values(), []int{1, 3, 4, 6}) {
+		t.Errorf("Expected [1 3 4 6], got %v", d.Values())
+	}
+	if d.Contains(2) || !d.Contains(6) {
+		t.Error("Contains returned an incorrect result")
+	}
+	d.Clear()
+	if !d.IsEmpty() {
+		t.Errorf("Expected Clear to empty the deque, got %v", d)
+	}
+}
+
+func TestDequeEnumerator(t *testing.T) {
+	d := NewFromSlice([]int{1, 2, 3})
+	d.PushFront(0)
+	out := make([]int, 0)
+	enum := d.GetEnumerator()
+	for enum.Next() {
+		out = append(out, enum.Current())
+	}
+	if !reflect.DeepEqual(out, []int{0, 1, 2, 3}) {
+		t.Errorf("Expected enumeration [0 1 2 3], got %v", out)
+	}
+}
+
+func TestDequeAll(t *testing.T) {
+	d := New[int](4)
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+
+	values := make([]int, 0)
+	for i, v := range d.All() {
+		if i != v {
+			t.Errorf("Expected index %d to hold %d, got %d", i, i, v)
+		}
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(values, []int{0, 1, 2, 3}) {
+		t.Errorf("Expected All to yield [0 1 2 3], got %v", values)
+	}
+
+	values = values[:0]
+	for _, v := range d.Backward() {
+		if v == 3 {
+			d.PopBack()
+			d.PopBack()
+		}
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(values, []int{3, 1, 0}) {
+		t.Errorf("Expected Backward to yield [3 1 0] after popping during iteration, got %v", values)
+	}
+}
@@ -0,0 +1,507 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package rbtree provides the red-black tree shared by the sorted collections.
+package rbtree
+
+import "golang.org/x/exp/constraints"
+
+// Compare is a comparator for ordered types.
+//
+// Returns a negative number if a < b, a positive number if a > b, and 0 otherwise.
+func Compare[T constraints.Ordered](a, b T) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// Node is a key/value pair stored in a Tree.
+//
+// Nodes keep their identity for as long as they are in the tree, so they may
+// be held onto between operations.
+type Node[K any, V any] struct {
+	Key    K
+	Value  V
+	left   *Node[K, V]
+	right  *Node[K, V]
+	parent *Node[K, V]
+	red    bool
+}
+
+// Tree is a red-black tree ordered by a comparator.
+//
+// Every leaf is the tree's sentinel node rather than nil, which keeps the
+// rebalancing code free of nil checks. The sentinel never leaves the package.
+type Tree[K any, V any] struct {
+	root     *Node[K, V]
+	sentinel *Node[K, V]
+	cmp      func(a, b K) int
+	count    int
+	cleared  int // incremented by Clear, which leaves the old nodes linked
+}
+
+// New creates an empty tree ordered by cmp.
+func New[K any, V any](cmp func(a, b K) int) *Tree[K, V] {
+	s := &Node[K, V]{}
+	return &Tree[K, V]{
+		root:     s,
+		sentinel: s,
+		cmp:      cmp,
+		count:    0,
+		cleared:  0,
+	}
+}
+
+// Len returns the number of nodes in the tree.
+func (t *Tree[K, V]) Len() int {
+	return t.count
+}
+
+// Cmp returns the comparator the tree is ordered by.
+func (t *Tree[K, V]) Cmp() func(a, b K) int {
+	return t.cmp
+}
+
+// Clear removes all nodes from the tree.
+func (t *Tree[K, V]) Clear() {
+	t.root = t.sentinel
+	t.count = 0
+	t.cleared += 1
+}
+
+// Get returns the node with the given key, or nil if it is not present.
+func (t *Tree[K, V]) Get(key K) *Node[K, V] {
+	x := t.root
+	for x != t.sentinel {
+		c := t.cmp(key, x.Key)
+		if c == 0 {
+			return x
+		}
+		if c < 0 {
+			x = x.left
+		} else {
+			x = x.right
+		}
+	}
+	return nil
+}
+
+// Put assigns the value to the key, inserting a new node if needed.
+//
+// Returns the node holding the key and true if the node was newly inserted.
+func (t *Tree[K, V]) Put(key K, value V) (*Node[K, V], bool) {
+	y := t.sentinel
+	x := t.root
+	for x != t.sentinel {
+		y = x
+		c := t.cmp(key, x.Key)
+		if c == 0 {
+			x.Value = value
+			return x, false
+		}
+		if c < 0 {
+			x = x.left
+		} else {
+			x = x.right
+		}
+	}
+
+	z := &Node[K, V]{
+		Key:    key,
+		Value:  value,
+		left:   t.sentinel,
+		right:  t.sentinel,
+		parent: y,
+		red:    true,
+	}
+	if y == t.sentinel {
+		t.root = z
+	} else if t.cmp(key, y.Key) < 0 {
+		y.left = z
+	} else {
+		y.right = z
+	}
+	t.insertFixup(z)
+	t.count += 1
+	return z, true
+}
+
+// Delete removes the node with the given key.
+//
+// Returns the removed node, or nil if the key was not present.
+func (t *Tree[K, V]) Delete(key K) *Node[K, V] {
+	z := t.Get(key)
+	if z == nil {
+		return nil
+	}
+	t.DeleteNode(z)
+	return z
+}
+
+// DeleteNode removes the node from the tree.
+//
+// The node must belong to the tree.
+func (t *Tree[K, V]) DeleteNode(z *Node[K, V]) {
+	y := z
+	yWasRed := y.red
+	var x *Node[K, V]
+	if z.left == t.sentinel {
+		x = z.right
+		t.transplant(z, z.right)
+	} else if z.right == t.sentinel {
+		x = z.left
+		t.transplant(z, z.left)
+	} else {
+		y = t.minimum(z.right)
+		yWasRed = y.red
+		x = y.right
+		if y.parent == z {
+			x.parent = y
+		} else {
+			t.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.red = z.red
+	}
+	if !yWasRed {
+		t.deleteFixup(x)
+	}
+	z.left, z.right, z.parent = nil, nil, nil
+	t.count -= 1
+}
+
+// Min returns the node with the smallest key, or nil if the tree is empty.
+func (t *Tree[K, V]) Min() *Node[K, V] {
+	if t.root == t.sentinel {
+		return nil
+	}
+	return t.minimum(t.root)
+}
+
+// Max returns the node with the largest key, or nil if the tree is empty.
+func (t *Tree[K, V]) Max() *Node[K, V] {
+	if t.root == t.sentinel {
+		return nil
+	}
+	return t.maximum(t.root)
+}
+
+// Next returns the node following n in key order, or nil if n is the last node.
+func (t *Tree[K, V]) Next(n *Node[K, V]) *Node[K, V] {
+	if n.right != t.sentinel {
+		return t.minimum(n.right)
+	}
+	y := n.parent
+	for y != t.sentinel && n == y.right {
+		n = y
+		y = y.parent
+	}
+	return t.orNil(y)
+}
+
+// Prev returns the node preceding n in key order, or nil if n is the first node.
+func (t *Tree[K, V]) Prev(n *Node[K, V]) *Node[K, V] {
+	if n.left != t.sentinel {
+		return t.maximum(n.left)
+	}
+	y := n.parent
+	for y != t.sentinel && n == y.left {
+		n = y
+		y = y.parent
+	}
+	return t.orNil(y)
+}
+
+// Floor returns the node with the largest key less than or equal to key, or nil.
+func (t *Tree[K, V]) Floor(key K) *Node[K, V] {
+	var best *Node[K, V]
+	x := t.root
+	for x != t.sentinel {
+		c := t.cmp(key, x.Key)
+		if c == 0 {
+			return x
+		}
+		if c < 0 {
+			x = x.left
+		} else {
+			best = x
+			x = x.right
+		}
+	}
+	return best
+}
+
+// Ceiling returns the node with the smallest key greater than or equal to key, or nil.
+func (t *Tree[K, V]) Ceiling(key K) *Node[K, V] {
+	var best *Node[K, V]
+	x := t.root
+	for x != t.sentinel {
+		c := t.cmp(key, x.Key)
+		if c == 0 {
+			return x
+		}
+		if c > 0 {
+			x = x.right
+		} else {
+			best = x
+			x = x.left
+		}
+	}
+	return best
+}
+
+// Lower returns the node with the largest key strictly less than key, or nil.
+func (t *Tree[K, V]) Lower(key K) *Node[K, V] {
+	var best *Node[K, V]
+	x := t.root
+	for x != t.sentinel {
+		if t.cmp(key, x.Key) <= 0 {
+			x = x.left
+		} else {
+			best = x
+			x = x.right
+		}
+	}
+	return best
+}
+
+// Higher returns the node with the smallest key strictly greater than key, or nil.
+func (t *Tree[K, V]) Higher(key K) *Node[K, V] {
+	var best *Node[K, V]
+	x := t.root
+	for x != t.sentinel {
+		if t.cmp(key, x.Key) >= 0 {
+			x = x.right
+		} else {
+			best = x
+			x = x.left
+		}
+	}
+	return best
+}
+
+// Ascend calls yield on each node in ascending key order until it returns false.
+//
+// yield may modify the tree. If the yielded node was removed, iteration
+// resumes at the next key greater than the removed one.
+func (t *Tree[K, V]) Ascend(yield func(*Node[K, V]) bool) {
+	t.AscendFrom(t.Min(), yield)
+}
+
+// AscendFrom is Ascend starting at n rather than the smallest key.
+// A nil n yields nothing.
+func (t *Tree[K, V]) AscendFrom(n *Node[K, V], yield func(*Node[K, V]) bool) {
+	cleared := t.cleared
+	for n != nil {
+		if !yield(n) {
+			return
+		}
+		if n.parent == nil || t.cleared != cleared {
+			cleared = t.cleared
+			n = t.Higher(n.Key)
+		} else {
+			n = t.Next(n)
+		}
+	}
+}
+
+// Descend calls yield on each node in descending key order until it returns false.
+//
+// yield may modify the tree. If the yielded node was removed, iteration
+// resumes at the next key less than the removed one.
+func (t *Tree[K, V]) Descend(yield func(*Node[K, V]) bool) {
+	t.DescendFrom(t.Max(), yield)
+}
+
+// DescendFrom is Descend starting at n rather than the largest key.
+// A nil n yields nothing.
+func (t *Tree[K, V]) DescendFrom(n *Node[K, V], yield func(*Node[K, V]) bool) {
+	cleared := t.cleared
+	for n != nil {
+		if !yield(n) {
+			return
+		}
+		if n.parent == nil || t.cleared != cleared {
+			cleared = t.cleared
+			n = t.Lower(n.Key)
+		} else {
+			n = t.Prev(n)
+		}
+	}
+}
+
+func (t *Tree[K, V]) orNil(n *Node[K, V]) *Node[K, V] {
+	if n == t.sentinel {
+		return nil
+	}
+	return n
+}
+
+func (t *Tree[K, V]) minimum(n *Node[K, V]) *Node[K, V] {
+	for n.left != t.sentinel {
+		n = n.left
+	}
+	return n
+}
+
+func (t *Tree[K, V]) maximum(n *Node[K, V]) *Node[K, V] {
+	for n.right != t.sentinel {
+		n = n.right
+	}
+	return n
+}
+
+func (t *Tree[K, V]) transplant(u, v *Node[K, V]) {
+	if u.parent == t.sentinel {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	v.parent = u.parent
+}
+
+func (t *Tree[K, V]) rotateLeft(x *Node[K, V]) {
+	y := x.right
+	x.right = y.left
+	if y.left != t.sentinel {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+	if x.parent == t.sentinel {
+		t.root = y
+	} else if x == x.parent.left {
+		x.parent.left = y
+	} else {
+		x.parent.right = y
+	}
+	y.left = x
+	x.parent = y
+}
+
+func (t *Tree[K, V]) rotateRight(x *Node[K, V]) {
+	y := x.left
+	x.left = y.right
+	if y.right != t.sentinel {
+		y.right.parent = x
+	}
+	y.parent = x.parent
+	if x.parent == t.sentinel {
+		t.root = y
+	} else if x == x.parent.right {
+		x.parent.right = y
+	} else {
+		x.parent.left = y
+	}
+	y.right = x
+	x.parent = y
+}
+
+func (t *Tree[K, V]) insertFixup(z *Node[K, V]) {
+	for z.parent.red {
+		gp := z.parent.parent
+		if z.parent == gp.left {
+			uncle := gp.right
+			if uncle.red {
+				z.parent.red = false
+				uncle.red = false
+				gp.red = true
+				z = gp
+				continue
+			}
+			if z == z.parent.right {
+				z = z.parent
+				t.rotateLeft(z)
+			}
+			z.parent.red = false
+			z.parent.parent.red = true
+			t.rotateRight(z.parent.parent)
+		} else {
+			uncle := gp.left
+			if uncle.red {
+				z.parent.red = false
+				uncle.red = false
+				gp.red = true
+				z = gp
+				continue
+			}
+			if z == z.parent.left {
+				z = z.parent
+				t.rotateRight(z)
+			}
+			z.parent.red = false
+			z.parent.parent.red = true
+			t.rotateLeft(z.parent.parent)
+		}
+	}
+	t.root.red = false
+}
+
+func (t *Tree[K, V]) deleteFixup(x *Node[K, V]) {
+	for x != t.root && !x.red {
+		if x == x.parent.left {
+			w := x.parent.right
+			if w.red {
+				w.red = false
+				x.parent.red = true
+				t.rotateLeft(x.parent)
+				w = x.parent.right
+			}
+			if !w.left.red && !w.right.red {
+				w.red = true
+				x = x.parent
+				continue
+			}
+			if !w.right.red {
+				w.left.red = false
+				w.red = true
+				t.rotateRight(w)
+				w = x.parent.right
+			}
+			w.red = x.parent.red
+			x.parent.red = false
+			w.right.red = false
+			t.rotateLeft(x.parent)
+			x = t.root
+		} else {
+			w := x.parent.left
+			if w.red {
+				w.red = false
+				x.parent.red = true
+				t.rotateRight(x.parent)
+				w = x.parent.left
+			}
+			if !w.left.red && !w.right.red {
+				w.red = true
+				x = x.parent
+				continue
+			}
+			if !w.left.red {
+				w.right.red = false
+				w.red = true
+				t.rotateLeft(w)
+				w = x.parent.left
+			}
+			w.red = x.parent.red
+			x.parent.red = false
+			w.left.red = false
+			t.rotateRight(x.parent)
+			x = t.root
+		}
+	}
+	x.red = false
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package rbtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+const PERM_SIZE int = 1024
+
+// checkInvariants verifies the red-black properties and returns the black height.
+func checkInvariants[K any, V any](t *testing.T, tree *Tree[K, V], n *Node[K, V]) int {
+	if n == tree.sentinel {
+		return 1
+	}
+	if n.red && (n.left.red || n.right.red) {
+		t.Fatal("Red node has a red child")
+	}
+	if n.left != tree.sentinel && tree.cmp(n.left.Key, n.Key) >= 0 {
+		t.Fatal("Left child is not less than its parent")
+	}
+	if n.right != tree.sentinel && tree.cmp(n.right.Key, n.Key) <= 0 {
+		t.Fatal("Right child is not greater than its parent")
+	}
+	lh := checkInvariants(t, tree, n.left)
+	rh := checkInvariants(t, tree, n.right)
+	if lh != rh {
+		t.Fatal("Black height mismatch")
+	}
+	if n.red {
+		return lh
+	}
+	return lh + 1
+}
+
+func TestTreeInsertDelete(t *testing.T) {
+	tree := New[int, int](Compare[int])
+	for _, v := range rand.Perm(PERM_SIZE) {
+		if _, inserted := tree.Put(v, v*2); !inserted {
+			t.Fatalf("Expected Put(%d) to insert", v)
+		}
+	}
+	if tree.root.red {
+		t.Fatal("Root is red")
+	}
+	checkInvariants(t, tree, tree.root)
+	if tree.Len() != PERM_SIZE {
+		t.Fatalf("Expected Len to return %d, got %d", PERM_SIZE, tree.Len())
+	}
+
+	if _, inserted := tree.Put(5, 0); inserted {
+		t.Error("Expected Put of an existing key to not insert")
+	}
+	if n := tree.Get(5); n == nil || n.Value != 0 {
+		t.Error("Expected Put of an existing key to replace the value")
+	}
+
+	for i, v := range rand.Perm(PERM_SIZE) {
+		if tree.Delete(v) == nil {
+			t.Fatalf("Expected Delete(%d) to remove a node", v)
+		}
+		if i%64 == 0 {
+			checkInvariants(t, tree, tree.root)
+		}
+	}
+	if tree.Len() != 0 || tree.Min() != nil {
+		t.Errorf("Expected tree to be empty, got Len %d", tree.Len())
+	}
+}
+
+func TestTreeOrder(t *testing.T) {
+	tree := New[int, struct{}](Compare[int])
+	for _, v := range rand.Perm(PERM_SIZE) {
+		tree.Put(v, struct{}{})
+	}
+	i := 0
+	for n := tree.Min(); n != nil; n = tree.Next(n) {
+		if n.Key != i {
+			t.Fatalf("Expected %d in forward order, got %d", i, n.Key)
+		}
+		i++
+	}
+	i = PERM_SIZE - 1
+	for n := tree.Max(); n != nil; n = tree.Prev(n) {
+		if n.Key != i {
+			t.Fatalf("Expected %d in reverse order, got %d", i, n.Key)
+		}
+		i--
+	}
+}
+
+func TestTreeNavigation(t *testing.T) {
+	tree := New[int, struct{}](Compare[int])
+	for _, v := range []int{10, 20, 30} {
+		tree.Put(v, struct{}{})
+	}
+	tests := []struct {
+		name string
+		f    func(int) *Node[int, struct{}]
+		key  int
+		want int // -1 for nil
+	}{
+		{"Floor", tree.Floor, 20, 20},
+		{"Floor", tree.Floor, 25, 20},
+		{"Floor", tree.Floor, 5, -1},
+		{"Ceiling", tree.Ceiling, 20, 20},
+		{"Ceiling", tree.Ceiling, 25, 30},
+		{"Ceiling", tree.Ceiling, 35, -1},
+		{"Lower", tree.Lower, 20, 10},
+		{"Lower", tree.Lower, 10, -1},
+		{"Higher", tree.Higher, 20, 30},
+		{"Higher", tree.Higher, 30, -1},
+	}
+	for _, tt := range tests {
+		n := tt.f(tt.key)
+		got := -1
+		if n != nil {
+			got = n.Key
+		}
+		if got != tt.want {
+			t.Errorf("%s(%d): expected %d, got %d", tt.name, tt.key, tt.want, got)
+		}
+	}
+}
+
+func TestTreeAscendDescend(t *testing.T) {
+	tree := New[int, struct{}](Compare[int])
+	for i := 0; i < 10; i++ {
+		tree.Put(i, struct{}{})
+	}
+	got := make([]int, 0)
+	tree.Ascend(func(n *Node[int, struct{}]) bool {
+		got = append(got, n.Key)
+		// Removing the yielded node and its successor must not break iteration
+		tree.DeleteNode(n)
+		tree.Delete(n.Key + 1)
+		return true
+	})
+	if len(got) != 5 || got[0] != 0 || got[4] != 8 {
+		t.Errorf("Expected Ascend to yield [0 2 4 6 8], got %v", got)
+	}
+
+	for i := 0; i < 10; i++ {
+		tree.Put(i, struct{}{})
+	}
+	got = got[:0]
+	tree.Descend(func(n *Node[int, struct{}]) bool {
+		got = append(got, n.Key)
+		if n.Key == 7 {
+			tree.Clear()
+			tree.Put(3, struct{}{})
+		}
+		return n.Key > 2
+	})
+	if len(got) != 4 || got[0] != 9 || got[3] != 3 {
+		t.Errorf("Expected Descend to yield [9 8 7 3], got %v", got)
+	}
+}
@@ -10,6 +10,7 @@ package arraylist
 
 import (
 	"fmt"
+	"iter"
 
 	"github.com/glasket/datastructures/collection/list"
 	"github.com/glasket/datastructures/interfaces/enumerator"
@@ -21,6 +22,7 @@ var _ list.IList[int] = (*List[int])(nil)
 
 type List[V comparable] struct {
 	elements []V
+	version  int
 }
 
 func New[V comparable]() *List[V] {
@@ -37,6 +39,7 @@ func NewFromSlice[V comparable](l []V) *List[V] {
 
 func (l *List[V]) Add(v V) {
 	l.elements = append(l.elements, v)
+	l.version += 1
 }
 
 func (l *List[V]) Remove(v V) {
@@ -45,6 +48,7 @@ func (l *List[V]) Remove(v V) {
 		return
 	}
 	l.elements = append(l.elements[:i], l.elements[i+1:]...)
+	l.version += 1
 }
 
 func (l *List[V]) InsertAt(i int, v V) error {
@@ -56,6 +60,7 @@ func (l *List[V]) InsertAt(i int, v V) error {
 		return err
 	}
 	l.elements = append(l.elements[:i], append([]V{v}, l.elements[i:]...)...)
+	l.version += 1
 	return nil
 }
 
@@ -64,6 +69,7 @@ func (l *List[V]) RemoveAt(i int) error {
 		return err
 	}
 	l.elements = append(l.elements[:i], l.elements[i+1:]...)
+	l.version += 1
 	return nil
 }
 
@@ -92,6 +98,7 @@ func (l *List[V]) Values() []V {
 
 func (l *List[V]) Clear() {
 	l.elements = make([]V, 0)
+	l.version += 1
 }
 
 func (l *List[V]) Contains(v V) bool {
@@ -120,8 +127,70 @@ func (l *List[V]) IndexOf(v V) (int, error) {
 	return -1, fmt.Errorf("value %v not found", v)
 }
 
+// GetEnumerator returns an enumerator.IEnumerator over the list.
+//
+// The enumerator panics with enumerator.ErrCollectionModified if values are
+// added, removed or sorted after it is created.
 func (l *List[V]) GetEnumerator() enumerator.IEnumerator[V] {
-	return enumerator.GetSliceEnumerable(l.elements).GetEnumerator()
+	return enumerator.GetVersionedEnumerator(
+		enumerator.GetSliceEnumerable(l.elements).GetEnumerator(),
+		func() int { return l.version },
+	)
+}
+
+// All returns an iterator over the indices and values of the list, in order.
+//
+// Like GetEnumerator, the iterator panics with enumerator.ErrCollectionModified
+// if values are added, removed or sorted during iteration, unless the loop
+// breaks straight after the change.
+func (l *List[V]) All() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		version := l.version
+		elements := l.elements
+		for i, v := range elements {
+			if !yield(i, v) {
+				return
+			}
+			l.checkVersion(version)
+		}
+	}
+}
+
+// Backward returns an iterator over the indices and values of the list, in reverse order.
+//
+// The iterator panics on modification like All.
+func (l *List[V]) Backward() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		version := l.version
+		elements := l.elements
+		for i := len(elements) - 1; i >= 0; i-- {
+			if !yield(i, elements[i]) {
+				return
+			}
+			l.checkVersion(version)
+		}
+	}
+}
+
+// Sort sorts the list in place by cmp, sorting partitions of the list in parallel.
+//
+// cmp must return a negative number, zero, or a positive number when a is
+// less than, equal to, or greater than b. The sort is not guaranteed to be stable.
+func (l *List[V]) Sort(cmp func(a, b V) int) {
+	copy(l.elements, enumerator.ParallelSortFunc[V](l, cmp).Values())
+	l.version += 1
+}
+
+// SortStable sorts the list in place by cmp, keeping equal values in their original order.
+func (l *List[V]) SortStable(cmp func(a, b V) int) {
+	copy(l.elements, enumerator.ParallelSortStableFunc[V](l, cmp).Values())
+	l.version += 1
+}
+
+func (l *List[V]) checkVersion(version int) {
+	if l.version != version {
+		panic(enumerator.ErrCollectionModified)
+	}
 }
 
 func (l *List[V]) checkBounds(i int) error {
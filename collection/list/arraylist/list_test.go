@@ -0,0 +1,119 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package arraylist_test
+
+import (
+	"iter"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	. "github.com/glasket/datastructures/collection/list/arraylist"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+const PERM_SIZE int = 1024
+
+func TestListAll(t *testing.T) {
+	l := NewFromSlice([]int{10, 20, 30})
+	indices := make([]int, 0)
+	values := make([]int, 0)
+	for i, v := range l.All() {
+		indices = append(indices, i)
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(indices, []int{0, 1, 2}) || !reflect.DeepEqual(values, []int{10, 20, 30}) {
+		t.Errorf("Expected All to yield [0 1 2] and [10 20 30], got %v and %v", indices, values)
+	}
+
+	values = values[:0]
+	for i, v := range l.Backward() {
+		if i == 0 {
+			break
+		}
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(values, []int{30, 20}) {
+		t.Errorf("Expected Backward to yield [30 20] before breaking, got %v", values)
+	}
+}
+
+func TestListSort(t *testing.T) {
+	l := NewFromSlice(rand.Perm(PERM_SIZE))
+	l.Sort(func(a, b int) int { return a - b })
+	for i, v := range l.Values() {
+		if v != i {
+			t.Fatalf("Expected Sort to place %d at index %d, got %d", i, i, v)
+		}
+	}
+
+	type pair struct{ key, order int }
+	pairs := make([]pair, PERM_SIZE)
+	for i := range pairs {
+		pairs[i] = pair{rand.Intn(8), i}
+	}
+	p := NewFromSlice(pairs)
+	p.SortStable(func(a, b pair) int { return a.key - b.key })
+	for i := 1; i < p.Count(); i++ {
+		prev, _ := p.Get(i - 1)
+		cur, _ := p.Get(i)
+		if prev.key > cur.key || (prev.key == cur.key && prev.order > cur.order) {
+			t.Fatalf("Expected SortStable to keep equal keys in order, got %v before %v", prev, cur)
+		}
+	}
+}
+
+func TestListEnumeratorModified(t *testing.T) {
+	l := NewFromSlice([]int{1, 2, 3, 4})
+	e := l.GetEnumerator()
+	e.Next()
+	l.Set(0, 10)
+	if !e.Next() || e.Current() != 2 {
+		t.Errorf("Expected Set not to invalidate the enumerator, got %d", e.Current())
+	}
+
+	defer func() {
+		if r := recover(); r != enumerator.ErrCollectionModified {
+			t.Errorf("Expected Next to panic with ErrCollectionModified, got %v", r)
+		}
+	}()
+	l.RemoveAt(0)
+	e.Next()
+	t.Error("Expected Next to panic after RemoveAt")
+}
+
+func TestListAllModified(t *testing.T) {
+	l := NewFromSlice([]int{1, 2, 3, 4})
+	for i, v := range l.All() {
+		l.Set(i, v*10)
+	}
+	if !reflect.DeepEqual(l.Values(), []int{10, 20, 30, 40}) {
+		t.Errorf("Expected Set not to invalidate All, got %v", l.Values())
+	}
+	for _, v := range l.Backward() {
+		if v == 30 {
+			l.Remove(v)
+			break
+		}
+	}
+
+	for _, seq := range []func() iter.Seq2[int, int]{l.All, l.Backward} {
+		func() {
+			defer func() {
+				if r := recover(); r != enumerator.ErrCollectionModified {
+					t.Errorf("Expected removing during iteration to panic with ErrCollectionModified, got %v", r)
+				}
+			}()
+			for _, v := range seq() {
+				l.Remove(v)
+			}
+			t.Error("Expected removing during iteration to panic")
+		}()
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package linkedlist
+
+import (
+	"iter"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+// GetEnumerator returns an enumerator.IEnumerator over the list from first to last.
+func (l *List[V]) GetEnumerator() enumerator.IEnumerator[V] {
+	return enumerator.GetSliceEnumerable(l.Values()).GetEnumerator()
+}
+
+// GetReverseEnumerator returns an enumerator.IEnumerator over the list from last to first.
+func (l *List[V]) GetReverseEnumerator() enumerator.IEnumerator[V] {
+	values := make([]V, 0, l.count)
+	for n := l.Last(); n != nil; n = n.Prev() {
+		values = append(values, n.Value)
+	}
+	return enumerator.GetSliceEnumerable(values).GetEnumerator()
+}
+
+// All returns an iterator over the indices and values of the list, from first to last.
+//
+// The current node may be removed during iteration, and the indices which
+// follow are shifted down to match.
+func (l *List[V]) All() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		i := 0
+		for n := l.First(); n != nil; {
+			next := n.Next()
+			if !yield(i, n.Value) {
+				return
+			}
+			// If n was removed, next has taken its index
+			if n.list != nil {
+				i++
+			}
+			n = next
+		}
+	}
+}
+
+// Backward returns an iterator over the indices and values of the list, from last to first.
+//
+// The current node may be removed during iteration.
+func (l *List[V]) Backward() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		i := l.count - 1
+		for n := l.Last(); n != nil; i-- {
+			prev := n.Prev()
+			if !yield(i, n.Value) {
+				return
+			}
+			n = prev
+		}
+	}
+}
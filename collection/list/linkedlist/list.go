@@ -0,0 +1,317 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package linkedlist
+
+import (
+	"fmt"
+
+	"github.com/glasket/datastructures/collection/list"
+)
+
+var _ list.IList[int] = (*List[int])(nil)
+
+// Node is an element of a List.
+//
+// Nodes act as handles, allowing for O(1) removal and insertion relative
+// to a known position in the list.
+type Node[V comparable] struct {
+	Value V
+	next  *Node[V]
+	prev  *Node[V]
+	list  *List[V]
+}
+
+// Next returns the next node in the list, or nil if n is the last node.
+func (n *Node[V]) Next() *Node[V] {
+	if n.list == nil || n.next == &n.list.root {
+		return nil
+	}
+	return n.next
+}
+
+// Prev returns the previous node in the list, or nil if n is the first node.
+func (n *Node[V]) Prev() *Node[V] {
+	if n.list == nil || n.prev == &n.list.root {
+		return nil
+	}
+	return n.prev
+}
+
+// List is a doubly linked list.
+//
+// The zero value is not usable, use New or NewFromSlice.
+type List[V comparable] struct {
+	root  Node[V] // sentinel, root.next is the first node and root.prev the last
+	count int
+}
+
+// New creates an empty list.
+func New[V comparable]() *List[V] {
+	l := &List[V]{}
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	return l
+}
+
+// NewFromSlice creates a list containing the values of the slice, in order.
+func NewFromSlice[V comparable](s []V) *List[V] {
+	l := New[V]()
+	for _, v := range s {
+		l.AddLast(v)
+	}
+	return l
+}
+
+// First returns the first node of the list, or nil if the list is empty.
+func (l *List[V]) First() *Node[V] {
+	if l.count == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Last returns the last node of the list, or nil if the list is empty.
+func (l *List[V]) Last() *Node[V] {
+	if l.count == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// Add appends the value to the end of the list.
+func (l *List[V]) Add(v V) {
+	l.AddLast(v)
+}
+
+// AddFirst inserts the value at the front of the list and returns its node.
+func (l *List[V]) AddFirst(v V) *Node[V] {
+	return l.insertAfter(v, &l.root)
+}
+
+// AddLast inserts the value at the end of the list and returns its node.
+func (l *List[V]) AddLast(v V) *Node[V] {
+	return l.insertAfter(v, l.root.prev)
+}
+
+// InsertBefore inserts the value immediately before mark and returns its node.
+//
+// Returns an error if mark is not a node of the list.
+func (l *List[V]) InsertBefore(v V, mark *Node[V]) (*Node[V], error) {
+	if err := l.checkNode(mark); err != nil {
+		return nil, err
+	}
+	return l.insertAfter(v, mark.prev), nil
+}
+
+// InsertAfter inserts the value immediately after mark and returns its node.
+//
+// Returns an error if mark is not a node of the list.
+func (l *List[V]) InsertAfter(v V, mark *Node[V]) (*Node[V], error) {
+	if err := l.checkNode(mark); err != nil {
+		return nil, err
+	}
+	return l.insertAfter(v, mark), nil
+}
+
+// RemoveFirst removes the first value of the list and returns it.
+//
+// Returns an error if the list is empty.
+func (l *List[V]) RemoveFirst() (V, error) {
+	if l.count == 0 {
+		return *new(V), fmt.Errorf("list is empty")
+	}
+	return l.unlink(l.root.next), nil
+}
+
+// RemoveLast removes the last value of the list and returns it.
+//
+// Returns an error if the list is empty.
+func (l *List[V]) RemoveLast() (V, error) {
+	if l.count == 0 {
+		return *new(V), fmt.Errorf("list is empty")
+	}
+	return l.unlink(l.root.prev), nil
+}
+
+// RemoveNode removes the node from the list and returns its value.
+//
+// Returns an error if n is not a node of the list.
+func (l *List[V]) RemoveNode(n *Node[V]) (V, error) {
+	if err := l.checkNode(n); err != nil {
+		return *new(V), err
+	}
+	return l.unlink(n), nil
+}
+
+// Remove removes the first occurrence of the value from the list.
+//
+// no-op if value is not present.
+func (l *List[V]) Remove(v V) {
+	if n := l.find(v); n != nil {
+		l.unlink(n)
+	}
+}
+
+// InsertAt inserts the value at the given index.
+//
+// An index equal to Count appends the value.
+func (l *List[V]) InsertAt(i int, v V) error {
+	if i == l.count {
+		l.AddLast(v)
+		return nil
+	}
+	n, err := l.nodeAt(i)
+	if err != nil {
+		return err
+	}
+	l.insertAfter(v, n.prev)
+	return nil
+}
+
+// RemoveAt removes the value at the given index.
+func (l *List[V]) RemoveAt(i int) error {
+	n, err := l.nodeAt(i)
+	if err != nil {
+		return err
+	}
+	l.unlink(n)
+	return nil
+}
+
+// Get returns the value at the given index.
+//
+// Walks from whichever end of the list is closer to i.
+func (l *List[V]) Get(i int) (V, error) {
+	n, err := l.nodeAt(i)
+	if err != nil {
+		return *new(V), err
+	}
+	return n.Value, nil
+}
+
+// Set replaces the value at the given index.
+func (l *List[V]) Set(i int, v V) error {
+	n, err := l.nodeAt(i)
+	if err != nil {
+		return err
+	}
+	n.Value = v
+	return nil
+}
+
+// Count returns the number of values in the list.
+func (l *List[V]) Count() int {
+	return l.count
+}
+
+// Values returns a new slice containing the values of the list, in order.
+func (l *List[V]) Values() []V {
+	values := make([]V, 0, l.count)
+	for n := l.First(); n != nil; n = n.Next() {
+		values = append(values, n.Value)
+	}
+	return values
+}
+
+// Clear removes all values from the list.
+//
+// Nodes obtained before the call are detached and can no longer be used
+// as handles.
+func (l *List[V]) Clear() {
+	for n := l.root.next; n != &l.root; {
+		next := n.next
+		n.next, n.prev, n.list = nil, nil, nil
+		n = next
+	}
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.count = 0
+}
+
+// Contains returns true if the value is present in the list.
+func (l *List[V]) Contains(v V) bool {
+	return l.find(v) != nil
+}
+
+// String returns the string representation of the list.
+func (l *List[V]) String() string {
+	return fmt.Sprintf("List[%v]", l.Values())
+}
+
+// IsEmpty returns true if the list is empty.
+func (l *List[V]) IsEmpty() bool {
+	return l.Count() == 0
+}
+
+// IndexOf returns the index of the first occurrence of the value.
+func (l *List[V]) IndexOf(v V) (int, error) {
+	i := 0
+	for n := l.First(); n != nil; n = n.Next() {
+		if n.Value == v {
+			return i, nil
+		}
+		i++
+	}
+	return -1, fmt.Errorf("value %v not found", v)
+}
+
+func (l *List[V]) insertAfter(v V, at *Node[V]) *Node[V] {
+	n := &Node[V]{
+		Value: v,
+		next:  at.next,
+		prev:  at,
+		list:  l,
+	}
+	at.next.prev = n
+	at.next = n
+	l.count += 1
+	return n
+}
+
+func (l *List[V]) unlink(n *Node[V]) V {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.next, n.prev, n.list = nil, nil, nil
+	l.count -= 1
+	return n.Value
+}
+
+func (l *List[V]) find(v V) *Node[V] {
+	for n := l.First(); n != nil; n = n.Next() {
+		if n.Value == v {
+			return n
+		}
+	}
+	return nil
+}
+
+func (l *List[V]) nodeAt(i int) (*Node[V], error) {
+	if i < 0 || i >= l.count {
+		return nil, fmt.Errorf("index %d out of bounds", i)
+	}
+	if i < l.count/2 {
+		n := l.root.next
+		for ; i > 0; i-- {
+			n = n.next
+		}
+		return n, nil
+	}
+	n := l.root.prev
+	for i = l.count - 1 - i; i > 0; i-- {
+		n = n.prev
+	}
+	return n, nil
+}
+
+func (l *List[V]) checkNode(n *Node[V]) error {
+	if n == nil || n.list != l {
+		return fmt.Errorf("node does not belong to this list")
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package linkedlist_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/glasket/datastructures/collection/list/linkedlist"
+)
+
+func TestNewList(t *testing.T) {
+	l := New[int]()
+	if l.Count() != 0 {
+		t.Fatalf("Expected New to return a list of size 0, got %d", l.Count())
+	}
+	if l.First() != nil || l.Last() != nil {
+		t.Error("Expected First and Last to be nil on an empty list")
+	}
+}
+
+func TestListAddFirstLast(t *testing.T) {
+	l := New[int]()
+	l.AddLast(2)
+	l.AddFirst(1)
+	l.AddLast(3)
+	if !reflect.DeepEqual(l.Values(), []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", l.Values())
+	}
+}
+
+func TestListRemoveFirstLast(t *testing.T) {
+	l := NewFromSlice([]int{1, 2, 3})
+	if v, err := l.RemoveFirst(); v != 1 || err != nil {
+		t.Errorf("Expected RemoveFirst to return 1, got %d (%v)", v, err)
+	}
+	if v, err := l.RemoveLast(); v != 3 || err != nil {
+		t.Errorf("Expected RemoveLast to return 3, got %d (%v)", v, err)
+	}
+	l.RemoveFirst()
+	if _, err := l.RemoveFirst(); err == nil {
+		t.Error("Expected RemoveFirst to error on an empty list")
+	}
+	if _, err := l.RemoveLast(); err == nil {
+		t.Error("Expected RemoveLast to error on an empty list")
+	}
+}
+
+func TestListNodeHandles(t *testing.T) {
+	l := New[int]()
+	two := l.AddLast(2)
+	if _, err := l.InsertBefore(1, two); err != nil {
+		t.Fatalf("Expected InsertBefore to not error, got %v", err)
+	}
+	if _, err := l.InsertAfter(3, two); err != nil {
+		t.Fatalf("Expected InsertAfter to not error, got %v", err)
+	}
+	if !reflect.DeepEqual(l.Values(), []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", l.Values())
+	}
+	if two.Prev().Value != 1 || two.Next().Value != 3 {
+		t.Error("Expected node links to reflect insertions")
+	}
+	if v, err := l.RemoveNode(two); v != 2 || err != nil {
+		t.Errorf("Expected RemoveNode to return 2, got %d (%v)", v, err)
+	}
+	if !reflect.DeepEqual(l.Values(), []int{1, 3}) {
+		t.Errorf("Expected [1 3], got %v", l.Values())
+	}
+	if _, err := l.RemoveNode(two); err == nil {
+		t.Error("Expected RemoveNode to error on a detached node")
+	}
+	other := NewFromSlice([]int{1})
+	if _, err := l.InsertAfter(4, other.First()); err == nil {
+		t.Error("Expected InsertAfter to error on a node from another list")
+	}
+}
+
+func TestListIndexed(t *testing.T) {
+	l := NewFromSlice([]int{0, 1, 2, 3, 4, 5})
+	for i := 0; i < 6; i++ {
+		if v, err := l.Get(i); v != i || err != nil {
+			t.Errorf("Expected Get(%d) to return %d, got %d (%v)", i, i, v, err)
+		}
+	}
+	if _, err := l.Get(6); err == nil {
+		t.Error("Expected Get to error out of bounds")
+	}
+	l.Set(4, 40)
+	l.InsertAt(6, 6)
+	l.InsertAt(0, -1)
+	l.RemoveAt(2)
+	if !reflect.DeepEqual(l.Values(), []int{-1, 0, 2, 3, 40, 5, 6}) {
+		t.Errorf("Expected [-1 0 2 3 40 5 6], got %v", l.Values())
+	}
+	if i, err := l.IndexOf(40); i != 4 || err != nil {
+		t.Errorf("Expected IndexOf(40) to return 4, got %d (%v)", i, err)
+	}
+	if _, err := l.IndexOf(1); err == nil {
+		t.Error("Expected IndexOf to error on a missing value")
+	}
+}
+
+func TestListRemoveAndClear(t *testing.T) {
+	l := NewFromSlice([]int{1, 2, 1})
+	l.Remove(1)
+	if !reflect.DeepEqual(l.Values(), []int{2, 1}) {
+		t.Errorf("Expected Remove to remove the first occurrence, got %v", l.Values())
+	}
+	if !l.Contains(1) || l.Contains(3) {
+		t.Error("Contains returned an incorrect result")
+	}
+	first := l.First()
+	l.Clear()
+	if !l.IsEmpty() {
+		t.Errorf("Expected Clear to empty the list, got %v", l)
+	}
+	if first.Next() != nil {
+		t.Error("Expected Clear to detach existing nodes")
+	}
+}
+
+func TestListEnumerators(t *testing.T) {
+	l := NewFromSlice([]int{1, 2, 3})
+	forward := make([]int, 0)
+	enum := l.GetEnumerator()
+	for enum.Next() {
+		forward = append(forward, enum.Current())
+	}
+	if !reflect.DeepEqual(forward, []int{1, 2, 3}) {
+		t.Errorf("Expected forward enumeration [1 2 3], got %v", forward)
+	}
+	reverse := make([]int, 0)
+	enum = l.GetReverseEnumerator()
+	for enum.Next() {
+		reverse = append(reverse, enum.Current())
+	}
+	if !reflect.DeepEqual(reverse, []int{3, 2, 1}) {
+		t.Errorf("Expected reverse enumeration [3 2 1], got %v", reverse)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	l := NewFromSlice([]int{1, 2, 3, 4})
+	indices := make([]int, 0)
+	values := make([]int, 0)
+	for i, v := range l.All() {
+		if v == 2 {
+			l.RemoveNode(l.First().Next())
+		}
+		indices = append(indices, i)
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(indices, []int{0, 1, 1, 2}) || !reflect.DeepEqual(values, []int{1, 2, 3, 4}) {
+		t.Errorf("Expected All to yield [0 1 1 2] and [1 2 3 4] while removing the current node, got %v and %v", indices, values)
+	}
+
+	indices = indices[:0]
+	values = values[:0]
+	for i, v := range l.Backward() {
+		indices = append(indices, i)
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(indices, []int{2, 1, 0}) || !reflect.DeepEqual(values, []int{4, 3, 1}) {
+		t.Errorf("Expected Backward to yield [2 1 0] and [4 3 1], got %v and %v", indices, values)
+	}
+}
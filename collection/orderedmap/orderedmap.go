@@ -8,90 +8,249 @@
 
 package orderedmap
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
 
-// TODO Rewrite to make use of the collection interfaces
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ enumerator.IEnumerable[Entry[int, int]] = (*OrderedMap[int, int])(nil)
+
+// Entry is a key/value pair of an OrderedMap.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// entry is an Entry linked into the map's ordering.
+type entry[K comparable, V any] struct {
+	Entry[K, V]
+	next *entry[K, V]
+	prev *entry[K, V]
+}
 
 // An OrderedMap maintains the insertion order of keys into a contained map.
+//
+// Each key maps to an entry of an intrusive doubly linked list, so removing
+// or repositioning a key is O(1).
 type OrderedMap[K comparable, V any] struct {
-	mapping map[K]V
-	keys    []K
+	mapping map[K]*entry[K, V]
+	head    *entry[K, V]
+	tail    *entry[K, V]
+	version int
 }
 
 // Constructs a new OrderedMap with instantiated fields.
 func NewOrderedMap[K comparable, V any](size int) OrderedMap[K, V] {
 	return OrderedMap[K, V]{
-		mapping: map[K]V{},
-		keys:    make([]K, 0, size),
+		mapping: make(map[K]*entry[K, V], size),
+		head:    nil,
+		tail:    nil,
+		version: 0,
 	}
 }
 
-// Returns the key slice of the OrderedMap
+// Returns the keys of the OrderedMap in order.
 func (m OrderedMap[K, V]) Keys() []K {
-	return m.keys
+	keys := make([]K, 0, len(m.mapping))
+	for e := m.head; e != nil; e = e.next {
+		keys = append(keys, e.Key)
+	}
+	return keys
+}
+
+// Returns the entries of the OrderedMap in order.
+func (m OrderedMap[K, V]) Values() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, len(m.mapping))
+	for e := m.head; e != nil; e = e.next {
+		entries = append(entries, e.Entry)
+	}
+	return entries
+}
+
+// Returns an enumerator.IEnumerator over the entries in order.
+//
+// The enumerator panics with enumerator.ErrCollectionModified if keys are
+// added, removed or reordered after it is created.
+func (m *OrderedMap[K, V]) GetEnumerator() enumerator.IEnumerator[Entry[K, V]] {
+	return enumerator.GetVersionedEnumerator(
+		enumerator.GetSliceEnumerable(m.Values()).GetEnumerator(),
+		func() int { return m.version },
+	)
+}
+
+// Returns an enumerable of the keys in order.
+func (m OrderedMap[K, V]) KeyEnumerable() enumerator.IEnumerable[K] {
+	return enumerator.GetSliceEnumerable(m.Keys())
+}
+
+// Returns an enumerable of the assigned values in key order.
+func (m OrderedMap[K, V]) ValueEnumerable() enumerator.IEnumerable[V] {
+	values := make([]V, 0, len(m.mapping))
+	for e := m.head; e != nil; e = e.next {
+		values = append(values, e.Value)
+	}
+	return enumerator.GetSliceEnumerable(values)
+}
+
+// Returns an iterator over the keys and values of the map, in order.
+//
+// Like GetEnumerator, the iterator panics with enumerator.ErrCollectionModified
+// if keys are added, removed or moved during iteration, unless the loop
+// breaks straight after the change. Assigning a new value to a key in place
+// is allowed.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		version := m.version
+		for e := m.head; e != nil; e = e.next {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+			m.checkVersion(version)
+		}
+	}
+}
+
+// Returns an iterator over the keys and values of the map, in reverse order.
+//
+// The iterator panics on modification like All.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		version := m.version
+		for e := m.tail; e != nil; e = e.prev {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+			m.checkVersion(version)
+		}
+	}
 }
 
 // Returns the assigned value at the given key, or an error if the key is not present in the map.
 func (m OrderedMap[K, V]) Get(key K) (value V, err error) {
-	var ok bool
-	value, ok = m.mapping[key]
+	e, ok := m.mapping[key]
 	if !ok {
 		return value, fmt.Errorf("key %v was not present", key)
 	}
-	return value, nil
+	return e.Value, nil
+}
+
+// Returns the first entry, or an error if the map is empty.
+func (m OrderedMap[K, V]) First() (Entry[K, V], error) {
+	if m.head == nil {
+		return Entry[K, V]{}, fmt.Errorf("map is empty")
+	}
+	return m.head.Entry, nil
+}
+
+// Returns the last entry, or an error if the map is empty.
+func (m OrderedMap[K, V]) Last() (Entry[K, V], error) {
+	if m.tail == nil {
+		return Entry[K, V]{}, fmt.Errorf("map is empty")
+	}
+	return m.tail.Entry, nil
 }
 
 // Assigns the given value to the given key.
 //
 // The key maintains its prior position. To update the position then use SetAndUpdate.
 func (m *OrderedMap[K, V]) Set(key K, value V) {
-	if !m.Contains(key) {
-		m.keys = append(m.keys, key)
+	if e, ok := m.mapping[key]; ok {
+		e.Value = value
+		return
 	}
-	m.mapping[key] = value
+	e := m.newEntry(key, value)
+	m.linkAfter(e, m.tail)
 }
 
 // Assigns the given value to the given key and shifts the key to the end of the order
 func (m *OrderedMap[K, V]) SetAndUpdate(key K, value V) {
-	if m.Contains(key) {
-		for i, v := range m.keys {
-			if key == v {
-				l := len(m.keys)
-				newKeys := make([]K, l)
-				for j, k := 0, 0; j < l-1; j, k = j+1, k+1 {
-					if j == i {
-						k += 1
-					}
-					newKeys[j] = m.keys[k]
-				}
-				newKeys[l-1] = key
-				m.keys = newKeys
-				break
-			}
-		}
-	} else {
-		m.keys = append(m.keys, key)
+	m.Set(key, value)
+	m.MoveToEnd(key)
+}
+
+// Moves the given key to the end of the order.
+//
+// Returns an error if the key was not assigned.
+func (m *OrderedMap[K, V]) MoveToEnd(key K) error {
+	e, ok := m.mapping[key]
+	if !ok {
+		return fmt.Errorf("key %v was not present", key)
 	}
-	m.mapping[key] = value
+	if e != m.tail {
+		m.unlink(e)
+		m.linkAfter(e, m.tail)
+	}
+	return nil
+}
+
+// Moves the given key to the front of the order.
+//
+// Returns an error if the key was not assigned.
+func (m *OrderedMap[K, V]) MoveToFront(key K) error {
+	e, ok := m.mapping[key]
+	if !ok {
+		return fmt.Errorf("key %v was not present", key)
+	}
+	if e != m.head {
+		m.unlink(e)
+		m.linkAfter(e, nil)
+	}
+	return nil
+}
+
+// Assigns the given value to the given key and positions the key immediately before mark.
+//
+// A key that is already present is moved. Returns an error if mark was not assigned.
+func (m *OrderedMap[K, V]) InsertBefore(mark K, key K, value V) error {
+	me, ok := m.mapping[mark]
+	if !ok {
+		return fmt.Errorf("key %v was not present", mark)
+	}
+	e := m.detachOrCreate(key, value, me)
+	if e != me {
+		m.linkAfter(e, me.prev)
+	}
+	return nil
+}
+
+// Assigns the given value to the given key and positions the key immediately after mark.
+//
+// A key that is already present is moved. Returns an error if mark was not assigned.
+func (m *OrderedMap[K, V]) InsertAfter(mark K, key K, value V) error {
+	me, ok := m.mapping[mark]
+	if !ok {
+		return fmt.Errorf("key %v was not present", mark)
+	}
+	e := m.detachOrCreate(key, value, me)
+	if e != me {
+		m.linkAfter(e, me)
+	}
+	return nil
 }
 
 // Removes the given key and its assigned value.
 //
 // Returns an error if the key was not assigned.
 func (m *OrderedMap[K, V]) Remove(key K) error {
-	if !m.Contains(key) {
+	e, ok := m.mapping[key]
+	if !ok {
 		return fmt.Errorf("key %v was not present", key)
 	}
 	delete(m.mapping, key)
-	for i, v := range m.keys {
-		if key == v {
-			m.keys = append(m.keys[:i], m.keys[i+1:]...)
-			break
-		}
-	}
+	m.unlink(e)
 	return nil
 }
 
+// Removes all keys from the map.
+func (m *OrderedMap[K, V]) Clear() {
+	m.mapping = make(map[K]*entry[K, V])
+	m.head = nil
+	m.tail = nil
+	m.version += 1
+}
+
 // Returns true if the given key is present in the underlying map, otherwise false.
 func (m OrderedMap[K, V]) Contains(key K) bool {
 	_, ok := m.mapping[key]
@@ -100,5 +259,75 @@ func (m OrderedMap[K, V]) Contains(key K) bool {
 
 // Returns the number of keys in the map.
 func (m OrderedMap[K, V]) Count() int {
-	return len(m.keys)
+	return len(m.mapping)
+}
+
+// Returns true if the map is empty.
+func (m OrderedMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// Returns the string representation of the map.
+func (m OrderedMap[K, V]) String() string {
+	return fmt.Sprintf("OrderedMap%v", m.Values())
+}
+
+func (m *OrderedMap[K, V]) newEntry(key K, value V) *entry[K, V] {
+	e := &entry[K, V]{Entry: Entry[K, V]{key, value}}
+	m.mapping[key] = e
+	return e
+}
+
+// detachOrCreate assigns the value to the key, unlinking the existing entry
+// unless it is mark, or creating an unlinked entry if the key is new.
+func (m *OrderedMap[K, V]) detachOrCreate(key K, value V, mark *entry[K, V]) *entry[K, V] {
+	e, ok := m.mapping[key]
+	if !ok {
+		return m.newEntry(key, value)
+	}
+	e.Value = value
+	if e != mark {
+		m.unlink(e)
+	}
+	return e
+}
+
+// linkAfter links e into the order after at, or at the front if at is nil.
+func (m *OrderedMap[K, V]) linkAfter(e *entry[K, V], at *entry[K, V]) {
+	e.prev = at
+	if at == nil {
+		e.next = m.head
+		m.head = e
+	} else {
+		e.next = at.next
+		at.next = e
+	}
+	if e.next == nil {
+		m.tail = e
+	} else {
+		e.next.prev = e
+	}
+	m.version += 1
+}
+
+func (m *OrderedMap[K, V]) unlink(e *entry[K, V]) {
+	if e.prev == nil {
+		m.head = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		m.tail = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+	e.next = nil
+	e.prev = nil
+	m.version += 1
+}
+
+func (m *OrderedMap[K, V]) checkVersion(version int) {
+	if m.version != version {
+		panic(enumerator.ErrCollectionModified)
+	}
 }
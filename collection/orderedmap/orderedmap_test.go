@@ -9,10 +9,14 @@
 package orderedmap_test
 
 import (
+	"encoding/json"
+	"iter"
+	"net/netip"
 	"reflect"
 	"testing"
 
 	. "github.com/glasket/datastructures/collection/orderedmap"
+	"github.com/glasket/datastructures/interfaces/enumerator"
 )
 
 // TODO Cleanup this mess
@@ -23,6 +27,10 @@ func TestNewOrderedMap(t *testing.T) {
 	if om.Count() != 0 {
 		t.Error("Count should be 0 at creation")
 	}
+	// Read-only methods must be callable on values which aren't addressable
+	if NewOrderedMap[string, int](0).Count() != 0 || NewOrderedMap[string, int](0).Contains("a") {
+		t.Error("A new map should be empty")
+	}
 }
 
 // Tests basic operations for correctness
@@ -136,3 +144,294 @@ func TestOrderedMapErrors(t *testing.T) {
 		t.Error("OrderedMap.Remove should error when removing a non-existent key")
 	}
 }
+
+// Tests O(1) repositioning operations
+func TestOrderedMapRepositioning(t *testing.T) {
+	om := NewOrderedMap[string, int](0)
+	for i, k := range []string{"a", "b", "c", "d"} {
+		om.Set(k, i)
+	}
+
+	if err := om.MoveToFront("c"); err != nil {
+		t.Errorf("MoveToFront should not error on an existing key, got %v", err)
+	}
+	if err := om.MoveToEnd("a"); err != nil {
+		t.Errorf("MoveToEnd should not error on an existing key, got %v", err)
+	}
+	exp := []string{"c", "b", "d", "a"}
+	if !reflect.DeepEqual(om.Keys(), exp) {
+		t.Errorf("Key order is broken.\nExpected: %v\nActual: %v", exp, om.Keys())
+	}
+
+	if err := om.InsertBefore("b", "x", 10); err != nil {
+		t.Errorf("InsertBefore should not error on an existing mark, got %v", err)
+	}
+	if err := om.InsertAfter("a", "y", 11); err != nil {
+		t.Errorf("InsertAfter should not error on an existing mark, got %v", err)
+	}
+	// Existing keys are moved and reassigned
+	if err := om.InsertAfter("c", "a", 12); err != nil {
+		t.Errorf("InsertAfter should not error on an existing mark, got %v", err)
+	}
+	exp = []string{"c", "a", "x", "b", "d", "y"}
+	if !reflect.DeepEqual(om.Keys(), exp) {
+		t.Errorf("Key order is broken.\nExpected: %v\nActual: %v", exp, om.Keys())
+	}
+	if v, _ := om.Get("a"); v != 12 {
+		t.Errorf("InsertAfter should reassign an existing key, got %v", v)
+	}
+	if om.Count() != len(exp) {
+		t.Errorf("Count is inaccurate, expected %v, got %v", len(exp), om.Count())
+	}
+
+	// Inserting a key relative to itself only reassigns it
+	om.InsertBefore("x", "x", 13)
+	if !reflect.DeepEqual(om.Keys(), exp) {
+		t.Errorf("Key order is broken.\nExpected: %v\nActual: %v", exp, om.Keys())
+	}
+
+	if e, _ := om.First(); e.Key != "c" {
+		t.Errorf("First should be c, got %v", e.Key)
+	}
+	if e, _ := om.Last(); e.Key != "y" || e.Value != 11 {
+		t.Errorf("Last should be {y 11}, got %v", e)
+	}
+
+	om.Remove("c")
+	om.Remove("y")
+	exp = []string{"a", "x", "b", "d"}
+	if !reflect.DeepEqual(om.Keys(), exp) {
+		t.Errorf("Key order is broken after removing the ends.\nExpected: %v\nActual: %v", exp, om.Keys())
+	}
+
+	om.Clear()
+	if !om.IsEmpty() || len(om.Keys()) != 0 {
+		t.Error("Clear should empty the map")
+	}
+}
+
+// Tests the enumerables over entries, keys, and values
+func TestOrderedMapEnumerables(t *testing.T) {
+	om := NewOrderedMap[string, int](0)
+	om.Set("b", 2)
+	om.Set("a", 1)
+	om.Set("c", 3)
+
+	entries := make([]Entry[string, int], 0)
+	enum := om.GetEnumerator()
+	for enum.Next() {
+		entries = append(entries, enum.Current())
+	}
+	exp := []Entry[string, int]{{"b", 2}, {"a", 1}, {"c", 3}}
+	if !reflect.DeepEqual(entries, exp) {
+		t.Errorf("Entry enumeration is broken.\nExpected: %v\nActual: %v", exp, entries)
+	}
+	if !reflect.DeepEqual(om.KeyEnumerable().Values(), []string{"b", "a", "c"}) {
+		t.Errorf("Key enumeration is broken, got %v", om.KeyEnumerable().Values())
+	}
+	if !reflect.DeepEqual(om.ValueEnumerable().Values(), []int{2, 1, 3}) {
+		t.Errorf("Value enumeration is broken, got %v", om.ValueEnumerable().Values())
+	}
+}
+
+// Tests the error conditions of the repositioning operations
+func TestOrderedMapRepositioningErrors(t *testing.T) {
+	om := NewOrderedMap[string, int](0)
+	if e := om.MoveToEnd("key"); e == nil {
+		t.Error("OrderedMap.MoveToEnd should error on a non-existent key")
+	}
+	if e := om.MoveToFront("key"); e == nil {
+		t.Error("OrderedMap.MoveToFront should error on a non-existent key")
+	}
+	if e := om.InsertBefore("mark", "key", 0); e == nil {
+		t.Error("OrderedMap.InsertBefore should error on a non-existent mark")
+	}
+	if e := om.InsertAfter("mark", "key", 0); e == nil {
+		t.Error("OrderedMap.InsertAfter should error on a non-existent mark")
+	}
+	if om.Contains("key") {
+		t.Error("A failed insert should not assign the key")
+	}
+	if _, e := om.First(); e == nil {
+		t.Error("OrderedMap.First should error on an empty map")
+	}
+}
+
+// Tests that JSON marshaling preserves key order
+func TestOrderedMapJson(t *testing.T) {
+	om := NewOrderedMap[string, int](0)
+	om.Set("z", 1)
+	om.Set("a", 2)
+	om.Set("m", 3)
+
+	jsonBytes, err := json.Marshal(&om)
+	if err != nil {
+		t.Fatalf("Marshal should not error, got %v", err)
+	}
+	if string(jsonBytes) != `{"z":1,"a":2,"m":3}` {
+		t.Errorf("Marshal did not preserve order, got %s", jsonBytes)
+	}
+
+	var om2 OrderedMap[string, int]
+	if err := json.Unmarshal([]byte(`{"q":1, "b":2, "x":3, "b":4}`), &om2); err != nil {
+		t.Fatalf("Unmarshal should not error, got %v", err)
+	}
+	exp := []string{"q", "b", "x"}
+	if !reflect.DeepEqual(om2.Keys(), exp) {
+		t.Errorf("Unmarshal did not preserve order.\nExpected: %v\nActual: %v", exp, om2.Keys())
+	}
+	if v, _ := om2.Get("b"); v != 4 {
+		t.Errorf("Unmarshal should keep the last value of a repeated key, got %v", v)
+	}
+
+	// Maps held by value must marshal the same as through a pointer
+	type config struct {
+		M OrderedMap[string, int]
+	}
+	jsonBytes, _ = json.Marshal(om)
+	if string(jsonBytes) != `{"z":1,"a":2,"m":3}` {
+		t.Errorf("Marshal of a value is broken, got %s", jsonBytes)
+	}
+	jsonBytes, _ = json.Marshal(config{M: om})
+	if string(jsonBytes) != `{"M":{"z":1,"a":2,"m":3}}` {
+		t.Errorf("Marshal of a struct field is broken, got %s", jsonBytes)
+	}
+	var cfg config
+	if err := json.Unmarshal(jsonBytes, &cfg); err != nil || !reflect.DeepEqual(cfg.M.Keys(), om.Keys()) {
+		t.Errorf("Round trip of a struct field is broken, got %v (%v)", cfg.M.Keys(), err)
+	}
+}
+
+// Tests the supported key types and nested maps
+func TestOrderedMapJsonKeys(t *testing.T) {
+	ints := NewOrderedMap[int8, string](0)
+	ints.Set(5, "five")
+	ints.Set(-1, "minus one")
+	jsonBytes, _ := json.Marshal(&ints)
+	if string(jsonBytes) != `{"5":"five","-1":"minus one"}` {
+		t.Errorf("Marshal of integer keys is broken, got %s", jsonBytes)
+	}
+	var ints2 OrderedMap[int8, string]
+	if err := json.Unmarshal(jsonBytes, &ints2); err != nil || !reflect.DeepEqual(ints2.Keys(), []int8{5, -1}) {
+		t.Errorf("Unmarshal of integer keys is broken, got %v (%v)", ints2.Keys(), err)
+	}
+	if err := json.Unmarshal([]byte(`{"300":""}`), &ints2); err == nil {
+		t.Error("Unmarshal should error on an integer key overflow")
+	}
+
+	addrs := NewOrderedMap[netip.Addr, bool](0)
+	addrs.Set(netip.MustParseAddr("10.0.0.2"), true)
+	addrs.Set(netip.MustParseAddr("10.0.0.1"), false)
+	jsonBytes, _ = json.Marshal(&addrs)
+	var addrs2 OrderedMap[netip.Addr, bool]
+	if err := json.Unmarshal(jsonBytes, &addrs2); err != nil || !reflect.DeepEqual(addrs2.Keys(), addrs.Keys()) {
+		t.Errorf("Round trip of TextMarshaler keys is broken, got %v (%v)", addrs2.Keys(), err)
+	}
+
+	var nested OrderedMap[string, OrderedMap[string, int]]
+	doc := `{"outer":{"y":1,"x":2},"first":{}}`
+	if err := json.Unmarshal([]byte(doc), &nested); err != nil {
+		t.Fatalf("Unmarshal of nested maps should not error, got %v", err)
+	}
+	jsonBytes, _ = json.Marshal(&nested)
+	if string(jsonBytes) != doc {
+		t.Errorf("Round trip of nested maps is broken.\nExpected: %s\nActual: %s", doc, jsonBytes)
+	}
+
+	floats := NewOrderedMap[float64, int](0)
+	floats.Set(1.5, 1)
+	if _, err := json.Marshal(&floats); err == nil {
+		t.Error("Marshal should error on an unsupported key type")
+	}
+	if err := json.Unmarshal([]byte(`[1, 2]`), &ints2); err == nil {
+		t.Error("Unmarshal should error on a non-object")
+	}
+}
+
+func TestOrderedMapAll(t *testing.T) {
+	m := NewOrderedMap[string, int](0)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	keys := make([]string, 0)
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		m.Set(k, v*10)
+	}
+	if !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Errorf("Expected All to yield [a b c] while reassigning values, got %v", keys)
+	}
+
+	keys = keys[:0]
+	for k := range m.All() {
+		if k == "b" {
+			m.Remove(k)
+			break
+		}
+	}
+	for k, v := range m.Backward() {
+		keys = append(keys, k)
+		if v < 10 {
+			t.Errorf("Expected %s to hold its reassigned value, got %d", k, v)
+		}
+	}
+	if !reflect.DeepEqual(keys, []string{"c", "a"}) {
+		t.Errorf("Expected Backward to yield [c a], got %v", keys)
+	}
+}
+
+func TestOrderedMapAllModified(t *testing.T) {
+	tests := []struct {
+		name   string
+		seq    func(m *OrderedMap[string, int]) iter.Seq2[string, int]
+		modify func(m *OrderedMap[string, int], k string)
+	}{
+		{"All moving the current key", (*OrderedMap[string, int]).All, func(m *OrderedMap[string, int], k string) { m.SetAndUpdate(k, 0) }},
+		{"All removing the next key", (*OrderedMap[string, int]).All, func(m *OrderedMap[string, int], k string) { m.Remove("b") }},
+		{"Backward moving the current key", (*OrderedMap[string, int]).Backward, func(m *OrderedMap[string, int], k string) { m.MoveToFront(k) }},
+		{"Backward removing the next key", (*OrderedMap[string, int]).Backward, func(m *OrderedMap[string, int], k string) { m.Remove("b") }},
+	}
+	for _, tt := range tests {
+		m := NewOrderedMap[string, int](0)
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m.Set("c", 3)
+		func() {
+			defer func() {
+				if r := recover(); r != enumerator.ErrCollectionModified {
+					t.Errorf("%s: expected a panic with ErrCollectionModified, got %v", tt.name, r)
+				}
+			}()
+			for k := range tt.seq(&m) {
+				if k == "b" {
+					t.Errorf("%s: expected b not to be yielded", tt.name)
+				}
+				tt.modify(&m, k)
+			}
+		}()
+	}
+}
+
+func TestOrderedMapEnumeratorModified(t *testing.T) {
+	m := NewOrderedMap[string, int](0)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	e := m.GetEnumerator()
+	e.Next()
+	m.Set("a", 10)
+	if !e.Next() || e.Current().Key != "b" {
+		t.Errorf("Expected reassigning a value not to invalidate the enumerator, got %v", e.Current())
+	}
+
+	defer func() {
+		if r := recover(); r != enumerator.ErrCollectionModified {
+			t.Errorf("Expected Next to panic with ErrCollectionModified, got %v", r)
+		}
+	}()
+	m.MoveToFront("c")
+	e.Next()
+	t.Error("Expected Next to panic after MoveToFront")
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package priorityqueue
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ enumerator.IEnumerable[int] = (*PriorityQueue[int])(nil)
+
+// Element is a handle to a value stored in a PriorityQueue.
+//
+// Handles allow a value to be changed or removed after it has been pushed.
+type Element[V any] struct {
+	Value V
+	index int // -1 once the element has left the queue
+}
+
+// PriorityQueue is a binary min-heap ordered by a less function.
+//
+// The value for which less reports true against every other value is
+// returned first. Use a greater-than function for a max-heap.
+type PriorityQueue[V any] struct {
+	heap []*Element[V]
+	less func(a, b V) bool
+}
+
+// New creates an empty priority queue ordered by less.
+func New[V any](less func(a, b V) bool) *PriorityQueue[V] {
+	return &PriorityQueue[V]{
+		heap: make([]*Element[V], 0),
+		less: less,
+	}
+}
+
+// NewFromSlice creates a priority queue ordered by less containing the values of the slice.
+//
+// Builds the heap in O(n).
+func NewFromSlice[V any](s []V, less func(a, b V) bool) *PriorityQueue[V] {
+	pq := New(less)
+	pq.PushAll(s...)
+	return pq
+}
+
+// Push adds the value to the queue and returns its handle.
+func (pq *PriorityQueue[V]) Push(v V) *Element[V] {
+	e := &Element[V]{Value: v, index: len(pq.heap)}
+	pq.heap = append(pq.heap, e)
+	pq.up(e.index)
+	return e
+}
+
+// PushAll adds all of the values to the queue and returns their handles.
+//
+// The heap is rebuilt bottom-up, which is O(n) rather than the O(n log n)
+// of pushing each value individually.
+func (pq *PriorityQueue[V]) PushAll(vs ...V) []*Element[V] {
+	elements := make([]*Element[V], len(vs))
+	for i, v := range vs {
+		e := &Element[V]{Value: v, index: len(pq.heap)}
+		pq.heap = append(pq.heap, e)
+		elements[i] = e
+	}
+	for i := len(pq.heap)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+	return elements
+}
+
+// Pop removes and returns the highest priority value.
+//
+// Returns an error if the queue is empty.
+func (pq *PriorityQueue[V]) Pop() (V, error) {
+	if len(pq.heap) == 0 {
+		return *new(V), fmt.Errorf("priority queue is empty")
+	}
+	return pq.removeAt(0), nil
+}
+
+// Peek returns the highest priority value without removing it.
+//
+// Returns an error if the queue is empty.
+func (pq *PriorityQueue[V]) Peek() (V, error) {
+	if len(pq.heap) == 0 {
+		return *new(V), fmt.Errorf("priority queue is empty")
+	}
+	return pq.heap[0].Value, nil
+}
+
+// Fix restores the heap ordering after e.Value has been changed in place.
+//
+// Returns an error if e is not in the queue.
+func (pq *PriorityQueue[V]) Fix(e *Element[V]) error {
+	if err := pq.checkElement(e); err != nil {
+		return err
+	}
+	if !pq.down(e.index) {
+		pq.up(e.index)
+	}
+	return nil
+}
+
+// Update sets the value of e and restores the heap ordering.
+//
+// Returns an error if e is not in the queue.
+func (pq *PriorityQueue[V]) Update(e *Element[V], v V) error {
+	if err := pq.checkElement(e); err != nil {
+		return err
+	}
+	e.Value = v
+	return pq.Fix(e)
+}
+
+// Remove removes e from the queue and returns its value.
+//
+// Returns an error if e is not in the queue.
+func (pq *PriorityQueue[V]) Remove(e *Element[V]) (V, error) {
+	if err := pq.checkElement(e); err != nil {
+		return *new(V), err
+	}
+	return pq.removeAt(e.index), nil
+}
+
+// Clear removes all values from the queue.
+func (pq *PriorityQueue[V]) Clear() {
+	for _, e := range pq.heap {
+		e.index = -1
+	}
+	pq.heap = make([]*Element[V], 0)
+}
+
+// Count returns the number of values in the queue.
+func (pq *PriorityQueue[V]) Count() int {
+	return len(pq.heap)
+}
+
+// IsEmpty returns true if the queue is empty.
+func (pq *PriorityQueue[V]) IsEmpty() bool {
+	return pq.Count() == 0
+}
+
+// String returns the string representation of the queue in heap order.
+func (pq *PriorityQueue[V]) String() string {
+	return fmt.Sprintf("PriorityQueue[%v]", pq.Values())
+}
+
+// Values returns a new slice of the values in heap order.
+//
+// Only the first value is guaranteed to be the highest priority, the rest
+// are in the order they are laid out in the heap.
+func (pq *PriorityQueue[V]) Values() []V {
+	values := make([]V, len(pq.heap))
+	for i, e := range pq.heap {
+		values[i] = e.Value
+	}
+	return values
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over the values in heap order.
+func (pq *PriorityQueue[V]) GetEnumerator() enumerator.IEnumerator[V] {
+	return enumerator.GetSliceEnumerable(pq.Values()).GetEnumerator()
+}
+
+// All returns an iterator over the values in heap order.
+func (pq *PriorityQueue[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for i := 0; i < len(pq.heap); i++ {
+			if !yield(pq.heap[i].Value) {
+				return
+			}
+		}
+	}
+}
+
+func (pq *PriorityQueue[V]) removeAt(i int) V {
+	e := pq.heap[i]
+	last := len(pq.heap) - 1
+	if i != last {
+		pq.swap(i, last)
+	}
+	pq.heap[last] = nil
+	pq.heap = pq.heap[:last]
+	if i != last {
+		if !pq.down(i) {
+			pq.up(i)
+		}
+	}
+	e.index = -1
+	return e.Value
+}
+
+func (pq *PriorityQueue[V]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.heap[i].Value, pq.heap[parent].Value) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down sifts the element at i toward the leaves and reports whether it moved.
+func (pq *PriorityQueue[V]) down(i int) bool {
+	start := i
+	n := len(pq.heap)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && pq.less(pq.heap[right].Value, pq.heap[child].Value) {
+			child = right
+		}
+		if !pq.less(pq.heap[child].Value, pq.heap[i].Value) {
+			break
+		}
+		pq.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+func (pq *PriorityQueue[V]) swap(i, j int) {
+	pq.heap[i], pq.heap[j] = pq.heap[j], pq.heap[i]
+	pq.heap[i].index = i
+	pq.heap[j].index = j
+}
+
+func (pq *PriorityQueue[V]) checkElement(e *Element[V]) error {
+	if e == nil || e.index < 0 || e.index >= len(pq.heap) || pq.heap[e.index] != e {
+		return fmt.Errorf("element is not in the priority queue")
+	}
+	return nil
+}
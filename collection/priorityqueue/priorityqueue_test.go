@@ -0,0 +1,144 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package priorityqueue_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/glasket/datastructures/collection/priorityqueue"
+)
+
+const PERM_SIZE int = 1024
+
+func lessInt(a, b int) bool {
+	return a < b
+}
+
+func TestNewPriorityQueue(t *testing.T) {
+	pq := New(lessInt)
+	if pq.Count() != 0 {
+		t.Fatalf("Expected New to return a queue of size 0, got %d", pq.Count())
+	}
+	if _, err := pq.Pop(); err == nil {
+		t.Error("Expected Pop to error on an empty queue")
+	}
+	if _, err := pq.Peek(); err == nil {
+		t.Error("Expected Peek to error on an empty queue")
+	}
+}
+
+func TestPriorityQueuePushPop(t *testing.T) {
+	pq := New(lessInt)
+	for _, v := range rand.Perm(PERM_SIZE) {
+		pq.Push(v)
+	}
+	if v, _ := pq.Peek(); v != 0 {
+		t.Errorf("Expected Peek to return 0, got %d", v)
+	}
+	for i := 0; i < PERM_SIZE; i++ {
+		if v, err := pq.Pop(); v != i || err != nil {
+			t.Fatalf("Expected Pop to return %d, got %d (%v)", i, v, err)
+		}
+	}
+	if !pq.IsEmpty() {
+		t.Errorf("Expected queue to be empty, got %v", pq)
+	}
+}
+
+func TestPriorityQueuePushAll(t *testing.T) {
+	pq := NewFromSlice(rand.Perm(PERM_SIZE), func(a, b int) bool { return a > b })
+	pq.PushAll(rand.Perm(PERM_SIZE)...)
+	if pq.Count() != 2*PERM_SIZE {
+		t.Fatalf("Expected Count to return %d, got %d", 2*PERM_SIZE, pq.Count())
+	}
+	for i := PERM_SIZE - 1; i >= 0; i-- {
+		for j := 0; j < 2; j++ {
+			if v, _ := pq.Pop(); v != i {
+				t.Fatalf("Expected Pop to return %d, got %d", i, v)
+			}
+		}
+	}
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	pq := New(lessInt)
+	handles := pq.PushAll(5, 10, 15, 20)
+
+	if err := pq.Update(handles[3], 1); err != nil {
+		t.Fatalf("Expected Update to not error, got %v", err)
+	}
+	if v, _ := pq.Peek(); v != 1 {
+		t.Errorf("Expected Peek to return 1 after Update, got %d", v)
+	}
+
+	handles[3].Value = 30
+	if err := pq.Fix(handles[3]); err != nil {
+		t.Fatalf("Expected Fix to not error, got %v", err)
+	}
+	if v, _ := pq.Peek(); v != 5 {
+		t.Errorf("Expected Peek to return 5 after Fix, got %d", v)
+	}
+
+	if v, err := pq.Remove(handles[1]); v != 10 || err != nil {
+		t.Errorf("Expected Remove to return 10, got %d (%v)", v, err)
+	}
+	if _, err := pq.Remove(handles[1]); err == nil {
+		t.Error("Expected Remove to error on a removed element")
+	}
+	if err := pq.Fix(handles[1]); err == nil {
+		t.Error("Expected Fix to error on a removed element")
+	}
+
+	expected := []int{5, 15, 30}
+	for _, e := range expected {
+		if v, _ := pq.Pop(); v != e {
+			t.Errorf("Expected Pop to return %d, got %d", e, v)
+		}
+	}
+}
+
+func TestPriorityQueueEnumerator(t *testing.T) {
+	pq := NewFromSlice(rand.Perm(PERM_SIZE), lessInt)
+	values := pq.Values()
+	if len(values) != PERM_SIZE {
+		t.Fatalf("Expected Values to return %d values, got %d", PERM_SIZE, len(values))
+	}
+	// Values must satisfy the heap property
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[(i-1)/2] {
+			t.Fatalf("Heap property violated at index %d", i)
+		}
+	}
+	enum := pq.GetEnumerator()
+	count := 0
+	for enum.Next() {
+		if enum.Current() != values[count] {
+			t.Errorf("Expected enumerator to yield %d at %d, got %d", values[count], count, enum.Current())
+		}
+		count++
+	}
+	if count != PERM_SIZE {
+		t.Errorf("Expected enumerator to yield %d values, got %d", PERM_SIZE, count)
+	}
+}
+
+func TestPriorityQueueAll(t *testing.T) {
+	pq := NewFromSlice(rand.Perm(PERM_SIZE), lessInt)
+	count := 0
+	for v := range pq.All() {
+		if count == 0 && v != 0 {
+			t.Errorf("Expected All to yield 0 first, got %d", v)
+		}
+		count++
+	}
+	if count != PERM_SIZE {
+		t.Errorf("Expected All to yield %d values, got %d", PERM_SIZE, count)
+	}
+}
@@ -0,0 +1,286 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/glasket/datastructures/collection"
+	"github.com/glasket/datastructures/collection/deque"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var _ enumerator.IEnumerable[int] = (*BlockingQueue[int])(nil)
+
+// ErrClosed is returned by the operations of a BlockingQueue after it has been closed.
+var ErrClosed = errors.New("queue is closed")
+
+// BlockingQueue is a FIFO queue safe for concurrent use, where producers wait
+// while it is full and consumers wait while it is empty.
+type BlockingQueue[V comparable] struct {
+	mu       sync.Mutex
+	items    *deque.Deque[V]
+	capacity int
+	closed   bool
+	takers   waiters // consumers waiting for a value
+	putters  waiters // producers waiting for room
+}
+
+// NewBlockingQueue creates an empty queue holding at most capacity values.
+//
+// If capacity <= 0 the queue is unbounded and Put never waits.
+func NewBlockingQueue[V comparable](capacity int) *BlockingQueue[V] {
+	return &BlockingQueue[V]{
+		items:    deque.New[V](max(capacity, 0)),
+		capacity: capacity,
+	}
+}
+
+// Put adds the value to the back of the queue, waiting while the queue is full.
+//
+// Returns ErrClosed if the queue is closed, or ctx.Err() if ctx is cancelled
+// before the value is added.
+func (q *BlockingQueue[V]) Put(ctx context.Context, v V) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if !q.full() {
+			q.items.PushBack(v)
+			q.takers.wakeOne()
+			q.mu.Unlock()
+			return nil
+		}
+		if err := q.wait(ctx, &q.putters); err != nil {
+			return err
+		}
+	}
+}
+
+// Take removes and returns the value at the front of the queue, waiting while the queue is empty.
+//
+// Values put before the queue was closed can still be taken. Returns
+// ErrClosed if the queue is closed and empty, or ctx.Err() if ctx is cancelled
+// before a value is removed.
+func (q *BlockingQueue[V]) Take(ctx context.Context) (V, error) {
+	for {
+		q.mu.Lock()
+		if v, err := q.items.PopFront(); err == nil {
+			q.putters.wakeOne()
+			q.mu.Unlock()
+			return v, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return *new(V), ErrClosed
+		}
+		if err := q.wait(ctx, &q.takers); err != nil {
+			return *new(V), err
+		}
+	}
+}
+
+// Offer adds the value to the back of the queue, waiting at most timeout while the queue is full.
+//
+// A timeout <= 0 doesn't wait. Returns context.DeadlineExceeded if the queue
+// is still full after the timeout, or ErrClosed if the queue is closed.
+func (q *BlockingQueue[V]) Offer(v V, timeout time.Duration) error {
+	ctx, cancel := timeoutContext(timeout)
+	defer cancel()
+	return q.Put(ctx, v)
+}
+
+// Poll removes and returns the value at the front of the queue, waiting at most timeout while the queue is empty.
+//
+// A timeout <= 0 doesn't wait. Returns context.DeadlineExceeded if the queue
+// is still empty after the timeout, or ErrClosed if the queue is closed and empty.
+func (q *BlockingQueue[V]) Poll(timeout time.Duration) (V, error) {
+	ctx, cancel := timeoutContext(timeout)
+	defer cancel()
+	return q.Take(ctx)
+}
+
+// Peek returns the value at the front of the queue without removing it.
+//
+// Returns an error if the queue is empty.
+func (q *BlockingQueue[V]) Peek() (V, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.PeekFront()
+}
+
+// DrainTo removes up to limit values from the front of the queue, adding them to c in order,
+// and returns the number of values moved.
+//
+// If limit <= 0 every value is moved.
+func (q *BlockingQueue[V]) DrainTo(c collection.ICollection[V], limit int) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := q.items.Count()
+	if limit > 0 && limit < n {
+		n = limit
+	}
+	for i := 0; i < n; i++ {
+		v, _ := q.items.PopFront()
+		c.Add(v)
+		q.putters.wakeOne()
+	}
+	return n
+}
+
+// SetCapacity changes the number of values the queue can hold, waking any
+// producers which now have room.
+//
+// If capacity <= 0 the queue becomes unbounded. Values already in the queue
+// are kept even if there are more of them than the new capacity.
+func (q *BlockingQueue[V]) SetCapacity(capacity int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.capacity = capacity
+	q.putters.wakeAll()
+}
+
+// Cap returns the number of values the queue can hold, or 0 if it is unbounded.
+func (q *BlockingQueue[V]) Cap() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.capacity <= 0 {
+		return 0
+	}
+	return q.capacity
+}
+
+// Close closes the queue, waking every waiting producer and consumer.
+//
+// Put fails with ErrClosed from then on, and Take fails with ErrClosed once
+// the remaining values have been taken. Closing a closed queue is a no-op.
+func (q *BlockingQueue[V]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.takers.wakeAll()
+	q.putters.wakeAll()
+}
+
+// IsClosed returns true if the queue has been closed.
+func (q *BlockingQueue[V]) IsClosed() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.closed
+}
+
+// Count returns the number of values in the queue.
+func (q *BlockingQueue[V]) Count() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Count()
+}
+
+// IsEmpty returns true if the queue is empty.
+func (q *BlockingQueue[V]) IsEmpty() bool {
+	return q.Count() == 0
+}
+
+// String returns the string representation of the queue.
+func (q *BlockingQueue[V]) String() string {
+	return fmt.Sprintf("BlockingQueue%v", q.Values())
+}
+
+// Values returns a slice of the values in the queue from front to back.
+func (q *BlockingQueue[V]) Values() []V {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Values()
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over a snapshot of the queue from front to back.
+func (q *BlockingQueue[V]) GetEnumerator() enumerator.IEnumerator[V] {
+	return enumerator.GetSliceEnumerable(q.Values()).GetEnumerator()
+}
+
+func (q *BlockingQueue[V]) full() bool {
+	return q.capacity > 0 && q.items.Count() >= q.capacity
+}
+
+// wait queues the caller on w and releases q.mu until it is woken or ctx is done.
+// q.mu must be held, and is not held when wait returns.
+func (q *BlockingQueue[V]) wait(ctx context.Context, w *waiters) error {
+	ch := w.add()
+	q.mu.Unlock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		if !w.remove(ch) {
+			// Woken as ctx finished, so pass the wakeup on rather than lose it
+			w.wakeOne()
+		}
+		q.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// waiters is a FIFO queue of goroutines blocked on one side of a BlockingQueue.
+//
+// Waking a single waiter per change, rather than broadcasting, keeps a busy
+// queue from waking every blocked producer and consumer on each Put and Take.
+// A woken waiter rechecks the queue under the lock, and queues again if
+// another goroutine got there first.
+type waiters []chan struct{}
+
+func (w *waiters) add() chan struct{} {
+	ch := make(chan struct{}, 1)
+	*w = append(*w, ch)
+	return ch
+}
+
+// remove dequeues ch, returning false if it was already woken.
+func (w *waiters) remove(ch chan struct{}) bool {
+	for i, c := range *w {
+		if c == ch {
+			*w = slices.Delete(*w, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+func (w *waiters) wakeOne() {
+	if len(*w) == 0 {
+		return
+	}
+	ch := (*w)[0]
+	(*w)[0] = nil
+	*w = (*w)[1:]
+	ch <- struct{}{}
+}
+
+func (w *waiters) wakeAll() {
+	for _, ch := range *w {
+		ch <- struct{}{}
+	}
+	*w = nil
+}
+
+// timeoutContext returns a context which expires after timeout, or one which
+// has already expired if timeout <= 0.
+func timeoutContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(context.Background(), time.Now().Add(max(timeout, 0)))
+}
@@ -0,0 +1,326 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glasket/datastructures/collection/list/arraylist"
+	. "github.com/glasket/datastructures/collection/queue"
+)
+
+const (
+	PRODUCERS    int = 4
+	CONSUMERS    int = 4
+	PER_PRODUCER int = 5000
+)
+
+func TestBlockingQueueFIFO(t *testing.T) {
+	q := NewBlockingQueue[int](0)
+	for i := 0; i < 100; i++ {
+		if err := q.Put(context.Background(), i); err != nil {
+			t.Fatalf("Expected Put on an unbounded queue to succeed, got %v", err)
+		}
+	}
+	if q.Count() != 100 || q.Cap() != 0 {
+		t.Errorf("Expected 100 values and no capacity, got %d and %d", q.Count(), q.Cap())
+	}
+	if v, err := q.Peek(); err != nil || v != 0 {
+		t.Errorf("Expected Peek to return 0, got %d", v)
+	}
+	for i := 0; i < 100; i++ {
+		if v, err := q.Take(context.Background()); err != nil || v != i {
+			t.Fatalf("Expected Take to return %d, got %d (%v)", i, v, err)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Error("Expected queue to be empty")
+	}
+	if _, err := q.Peek(); err == nil {
+		t.Error("Expected Peek on an empty queue to fail")
+	}
+}
+
+func TestBlockingQueueBackpressure(t *testing.T) {
+	q := NewBlockingQueue[int](2)
+	q.Put(context.Background(), 1)
+	q.Put(context.Background(), 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.Put(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected Put on a full queue to time out, got %v", err)
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		q.Take(context.Background())
+	}()
+	if err := q.Put(context.Background(), 3); err != nil {
+		t.Fatalf("Expected Put to wait for space, got %v", err)
+	}
+	if !slices.Equal(q.Values(), []int{2, 3}) {
+		t.Errorf("Expected queue to be [2 3], got %v", q.Values())
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		q.SetCapacity(3)
+	}()
+	if err := q.Put(context.Background(), 4); err != nil {
+		t.Fatalf("Expected Put to wait for the capacity to grow, got %v", err)
+	}
+	if q.Cap() != 3 {
+		t.Errorf("Expected Cap to be 3, got %d", q.Cap())
+	}
+}
+
+func TestBlockingQueueTimeouts(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	if _, err := q.Poll(0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected Poll(0) on an empty queue to fail immediately, got %v", err)
+	}
+	if err := q.Offer(1, 0); err != nil {
+		t.Errorf("Expected Offer(0) on an empty queue to succeed, got %v", err)
+	}
+
+	start := time.Now()
+	if err := q.Offer(2, 10*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected Offer on a full queue to time out, got %v", err)
+	}
+	if time.Since(start) < 10*time.Millisecond {
+		t.Error("Expected Offer to wait for the timeout")
+	}
+
+	if v, err := q.Poll(0); err != nil || v != 1 {
+		t.Errorf("Expected Poll(0) to return 1, got %d (%v)", v, err)
+	}
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		q.Offer(5, 0)
+	}()
+	if v, err := q.Poll(time.Second); err != nil || v != 5 {
+		t.Errorf("Expected Poll to wait for 5, got %d (%v)", v, err)
+	}
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := q.Take(context.Background())
+			errs <- err
+		}()
+	}
+	time.Sleep(5 * time.Millisecond)
+	q.Close()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if !errors.Is(err, ErrClosed) {
+			t.Errorf("Expected waiting Take to fail with ErrClosed, got %v", err)
+		}
+	}
+
+	q = NewBlockingQueue[int](1)
+	q.Put(context.Background(), 1)
+	done := make(chan error)
+	go func() { done <- q.Put(context.Background(), 2) }()
+	time.Sleep(5 * time.Millisecond)
+	q.Close()
+	q.Close()
+	if err := <-done; !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected waiting Put to fail with ErrClosed, got %v", err)
+	}
+	if !q.IsClosed() {
+		t.Error("Expected queue to be closed")
+	}
+	// Remaining values can still be taken
+	if v, err := q.Take(context.Background()); err != nil || v != 1 {
+		t.Errorf("Expected Take to return 1 after Close, got %d (%v)", v, err)
+	}
+	if _, err := q.Take(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected Take on a closed empty queue to fail with ErrClosed, got %v", err)
+	}
+}
+
+func TestBlockingQueueDrainTo(t *testing.T) {
+	q := NewBlockingQueue[int](4)
+	for i := 0; i < 4; i++ {
+		q.Put(context.Background(), i)
+	}
+	done := make(chan error)
+	go func() { done <- q.Put(context.Background(), 4) }()
+
+	l := arraylist.New[int]()
+	if n := q.DrainTo(l, 3); n != 3 {
+		t.Errorf("Expected DrainTo to move 3 values, got %d", n)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Expected DrainTo to wake a waiting Put, got %v", err)
+	}
+	if n := q.DrainTo(l, 0); n != 2 {
+		t.Errorf("Expected DrainTo to move the remaining 2 values, got %d", n)
+	}
+	if !slices.Equal(l.Values(), []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Expected drained values to be [0 1 2 3 4], got %v", l.Values())
+	}
+	if n := q.DrainTo(l, 0); n != 0 || !q.IsEmpty() {
+		t.Errorf("Expected DrainTo on an empty queue to move nothing, got %d", n)
+	}
+}
+
+func TestBlockingQueueEnumerator(t *testing.T) {
+	q := NewBlockingQueue[int](0)
+	for i := 0; i < 3; i++ {
+		q.Put(context.Background(), i)
+	}
+	e := q.GetEnumerator()
+	q.Take(context.Background())
+	var got []int
+	for e.Next() {
+		got = append(got, e.Current())
+	}
+	if !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Expected enumerator to iterate the snapshot [0 1 2], got %v", got)
+	}
+	if q.String() != "BlockingQueue[1 2]" {
+		t.Errorf("Expected String to be BlockingQueue[1 2], got %s", q.String())
+	}
+}
+
+func TestBlockingQueueConcurrent(t *testing.T) {
+	q := NewBlockingQueue[int](16)
+	var producers, consumers sync.WaitGroup
+	seen := make([][]int, CONSUMERS)
+	for p := 0; p < PRODUCERS; p++ {
+		producers.Add(1)
+		go func(p int) {
+			defer producers.Done()
+			for i := 0; i < PER_PRODUCER; i++ {
+				if err := q.Put(context.Background(), p*PER_PRODUCER+i); err != nil {
+					t.Errorf("Expected Put to succeed, got %v", err)
+					return
+				}
+			}
+		}(p)
+	}
+	for c := 0; c < CONSUMERS; c++ {
+		consumers.Add(1)
+		go func(c int) {
+			defer consumers.Done()
+			for {
+				v, err := q.Take(context.Background())
+				if err != nil {
+					return
+				}
+				seen[c] = append(seen[c], v)
+			}
+		}(c)
+	}
+	producers.Wait()
+	q.Close()
+	consumers.Wait()
+
+	all := slices.Concat(seen...)
+	slices.Sort(all)
+	if len(all) != PRODUCERS*PER_PRODUCER {
+		t.Fatalf("Expected %d values to be taken, got %d", PRODUCERS*PER_PRODUCER, len(all))
+	}
+	for i, v := range all {
+		if v != i {
+			t.Fatalf("Expected every value to be taken exactly once, got %d at %d", v, i)
+		}
+	}
+}
+
+func TestBlockingQueueWakesOneSide(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	q.Put(context.Background(), 0)
+	done := make(chan error, PRODUCERS)
+	for p := 0; p < PRODUCERS; p++ {
+		go func(p int) { done <- q.Put(context.Background(), p+1) }(p)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	// Each Take makes room for exactly one waiting producer
+	for i := 0; i < PRODUCERS; i++ {
+		q.Take(context.Background())
+		if err := <-done; err != nil {
+			t.Fatalf("Expected a waiting Put to succeed, got %v", err)
+		}
+		select {
+		case <-done:
+			t.Fatal("Expected only one waiting Put to proceed per Take")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	if q.Count() != 1 {
+		t.Errorf("Expected 1 value, got %d", q.Count())
+	}
+}
+
+func TestBlockingQueueTimeoutsDontLoseWakeups(t *testing.T) {
+	q := NewBlockingQueue[int](4)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	put, taken := 0, 0
+	for p := 0; p < PRODUCERS; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				if q.Offer(i, time.Duration(i%3)*time.Microsecond) == nil {
+					mu.Lock()
+					put++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for c := 0; c < CONSUMERS; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				if _, err := q.Poll(time.Duration(i%3) * time.Microsecond); err == nil {
+					mu.Lock()
+					taken++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if put != taken+q.Count() {
+		t.Errorf("Expected every value put to be taken or queued, put %d, took %d with %d queued", put, taken, q.Count())
+	}
+
+	// Waiters that timed out must not be left queued to swallow later wakeups
+	q.DrainTo(arraylist.New[int](), 0)
+	done := make(chan int)
+	go func() {
+		v, _ := q.Poll(time.Second)
+		done <- v
+	}()
+	time.Sleep(time.Millisecond)
+	q.Offer(42, 0)
+	if v := <-done; v != 42 {
+		t.Errorf("Expected a waiting Poll to receive 42, got %d", v)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package lockfree provides a bounded multi-producer/multi-consumer queue
+// which never takes a lock.
+package lockfree
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// cacheLine is the padding used to keep hot fields on separate cache lines.
+type cacheLine [64]byte
+
+// cell is a slot of the ring buffer.
+//
+// sequence tells producers and consumers whose turn the slot is: it equals
+// the enqueue position when the slot is free for that position, and the
+// position plus one once the value has been written.
+type cell[V any] struct {
+	sequence atomic.Uint64
+	value    V
+}
+
+// Queue is a bounded FIFO queue safe for any number of concurrent producers
+// and consumers, based on Dmitry Vyukov's bounded MPMC queue.
+//
+// Each slot carries a sequence number, so a producer or consumer claims a
+// slot with a single compare-and-swap on the shared position and then uses
+// the slot without further synchronization.
+type Queue[V any] struct {
+	_          cacheLine
+	enqueuePos atomic.Uint64
+	_          cacheLine
+	dequeuePos atomic.Uint64
+	_          cacheLine
+	mask       uint64
+	buffer     []cell[V]
+}
+
+// New creates an empty queue holding at least capacity values.
+//
+// The capacity is rounded up to a power of two, and is at least 2.
+func New[V any](capacity int) *Queue[V] {
+	n := 2
+	for n < capacity {
+		n <<= 1
+	}
+	q := &Queue[V]{
+		mask:   uint64(n - 1),
+		buffer: make([]cell[V], n),
+	}
+	for i := range q.buffer {
+		q.buffer[i].sequence.Store(uint64(i))
+	}
+	return q
+}
+
+// TryEnqueue adds the value to the back of the queue and returns true,
+// or returns false without waiting if the queue is full.
+func (q *Queue[V]) TryEnqueue(v V) bool {
+	pos := q.enqueuePos.Load()
+	for {
+		c := &q.buffer[pos&q.mask]
+		seq := c.sequence.Load()
+		switch dif := int64(seq) - int64(pos); {
+		case dif == 0:
+			if q.enqueuePos.CompareAndSwap(pos, pos+1) {
+				c.value = v
+				c.sequence.Store(pos + 1)
+				return true
+			}
+			pos = q.enqueuePos.Load()
+		case dif < 0:
+			// The slot still holds the value from the previous lap
+			return false
+		default:
+			// Another producer claimed the slot first
+			pos = q.enqueuePos.Load()
+		}
+	}
+}
+
+// TryDequeue removes and returns the value at the front of the queue,
+// or returns false without waiting if the queue is empty.
+func (q *Queue[V]) TryDequeue() (V, bool) {
+	pos := q.dequeuePos.Load()
+	for {
+		c := &q.buffer[pos&q.mask]
+		seq := c.sequence.Load()
+		switch dif := int64(seq) - int64(pos+1); {
+		case dif == 0:
+			if q.dequeuePos.CompareAndSwap(pos, pos+1) {
+				v := c.value
+				c.value = *new(V)
+				c.sequence.Store(pos + q.mask + 1)
+				return v, true
+			}
+			pos = q.dequeuePos.Load()
+		case dif < 0:
+			// The slot hasn't been written for this lap
+			return *new(V), false
+		default:
+			// Another consumer claimed the slot first
+			pos = q.dequeuePos.Load()
+		}
+	}
+}
+
+// Enqueue adds the value to the back of the queue, waiting while the queue is full.
+//
+// The queue has no way to wake waiters, so Enqueue polls with an increasing
+// backoff. Returns ctx.Err() if ctx is cancelled before the value is added.
+func (q *Queue[V]) Enqueue(ctx context.Context, v V) error {
+	var b backoff
+	for !q.TryEnqueue(v) {
+		if err := b.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dequeue removes and returns the value at the front of the queue, waiting while the queue is empty.
+//
+// See Enqueue for how it waits. Returns ctx.Err() if ctx is cancelled before
+// a value is removed.
+func (q *Queue[V]) Dequeue(ctx context.Context) (V, error) {
+	var b backoff
+	for {
+		if v, ok := q.TryDequeue(); ok {
+			return v, nil
+		}
+		if err := b.wait(ctx); err != nil {
+			return *new(V), err
+		}
+	}
+}
+
+// Len returns the number of values in the queue.
+//
+// The result is only a snapshot when there are concurrent producers or consumers.
+func (q *Queue[V]) Len() int {
+	for {
+		deq := q.dequeuePos.Load()
+		enq := q.enqueuePos.Load()
+		if deq == q.dequeuePos.Load() {
+			if n := int(enq - deq); n > 0 {
+				return min(n, q.Cap())
+			}
+			return 0
+		}
+	}
+}
+
+// Cap returns the number of values the queue can hold.
+func (q *Queue[V]) Cap() int {
+	return len(q.buffer)
+}
+
+// backoff spins, then yields, then sleeps for increasing durations between polls.
+type backoff struct {
+	attempt int
+}
+
+const (
+	spinAttempts  = 16
+	yieldAttempts = 64
+	maxSleep      = time.Millisecond
+)
+
+func (b *backoff) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.attempt++
+	switch {
+	case b.attempt <= spinAttempts:
+	case b.attempt <= yieldAttempts:
+		runtime.Gosched()
+	default:
+		d := time.Microsecond << min(b.attempt-yieldAttempts, 10)
+		if d > maxSleep {
+			d = maxSleep
+		}
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package lockfree_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/glasket/datastructures/collection/queue/lockfree"
+)
+
+const (
+	PRODUCERS    int = 4
+	CONSUMERS    int = 4
+	PER_PRODUCER int = 10000
+)
+
+func TestNewQueue(t *testing.T) {
+	for _, c := range []struct{ requested, expected int }{{0, 2}, {1, 2}, {2, 2}, {3, 4}, {1000, 1024}} {
+		q := New[int](c.requested)
+		if q.Cap() != c.expected || q.Len() != 0 {
+			t.Errorf("Expected New(%d) to have capacity %d, got %d", c.requested, c.expected, q.Cap())
+		}
+	}
+	if _, ok := New[int](4).TryDequeue(); ok {
+		t.Error("Expected TryDequeue on an empty queue to fail")
+	}
+}
+
+func TestQueueFIFO(t *testing.T) {
+	q := New[int](4)
+	// Go around the ring a few times
+	for lap := 0; lap < 3; lap++ {
+		for i := 0; i < 4; i++ {
+			if !q.TryEnqueue(lap*4 + i) {
+				t.Fatalf("Expected TryEnqueue to succeed with %d values queued", i)
+			}
+		}
+		if q.TryEnqueue(-1) {
+			t.Fatal("Expected TryEnqueue on a full queue to fail")
+		}
+		if q.Len() != 4 {
+			t.Errorf("Expected Len to be 4, got %d", q.Len())
+		}
+		for i := 0; i < 4; i++ {
+			if v, ok := q.TryDequeue(); !ok || v != lap*4+i {
+				t.Fatalf("Expected TryDequeue to return %d, got %d", lap*4+i, v)
+			}
+		}
+	}
+}
+
+func TestQueueBlocking(t *testing.T) {
+	q := New[int](2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Dequeue(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected Dequeue on an empty queue to time out, got %v", err)
+	}
+
+	q.TryEnqueue(1)
+	q.TryEnqueue(2)
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		q.TryDequeue()
+	}()
+	if err := q.Enqueue(context.Background(), 3); err != nil {
+		t.Fatalf("Expected Enqueue to wait for space, got %v", err)
+	}
+	if v, _ := q.Dequeue(context.Background()); v != 2 {
+		t.Errorf("Expected Dequeue to return 2, got %d", v)
+	}
+	if v, _ := q.Dequeue(context.Background()); v != 3 {
+		t.Errorf("Expected Dequeue to return 3, got %d", v)
+	}
+}
+
+func TestQueueStress(t *testing.T) {
+	q := New[int](64)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var producers sync.WaitGroup
+	for p := 0; p < PRODUCERS; p++ {
+		producers.Add(1)
+		go func(p int) {
+			defer producers.Done()
+			for i := 0; i < PER_PRODUCER; i++ {
+				if err := q.Enqueue(ctx, p*PER_PRODUCER+i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	results := make([][]int, CONSUMERS)
+	var consumers sync.WaitGroup
+	total := PRODUCERS * PER_PRODUCER
+	remaining := make(chan struct{}, total)
+	for i := 0; i < total; i++ {
+		remaining <- struct{}{}
+	}
+	for c := 0; c < CONSUMERS; c++ {
+		consumers.Add(1)
+		go func(c int) {
+			defer consumers.Done()
+			for range remaining {
+				v, err := q.Dequeue(ctx)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[c] = append(results[c], v)
+			}
+		}(c)
+	}
+	close(remaining)
+	producers.Wait()
+	consumers.Wait()
+
+	seen := make([]bool, total)
+	for c, r := range results {
+		last := make([]int, PRODUCERS)
+		for p := range last {
+			last[p] = -1
+		}
+		for _, v := range r {
+			if seen[v] {
+				t.Fatalf("Expected %d to be dequeued once", v)
+			}
+			seen[v] = true
+			// Each consumer sees each producer's values in order
+			p, i := v/PER_PRODUCER, v%PER_PRODUCER
+			if i <= last[p] {
+				t.Fatalf("Expected consumer %d to see producer %d in order, got %d after %d", c, p, i, last[p])
+			}
+			last[p] = i
+		}
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("Expected %d to be dequeued", v)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Expected the queue to be empty, got %d values", q.Len())
+	}
+}
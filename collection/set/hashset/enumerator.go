@@ -12,6 +12,12 @@ import "github.com/glasket/datastructures/interfaces/enumerator"
 
 // TODO Might be better to extract this out to the Set package
 // Returns an enumerator.Enumerator for the set.
+//
+// The enumerator panics with enumerator.ErrCollectionModified if the set is
+// modified after it is created.
 func (s *Set[V]) GetEnumerator() enumerator.IEnumerator[V] {
-	return enumerator.GetMapKeyEnumerable(s.set).GetEnumerator()
+	return enumerator.GetVersionedEnumerator(
+		enumerator.GetMapKeyEnumerable(s.set).GetEnumerator(),
+		func() int { return s.version },
+	)
 }
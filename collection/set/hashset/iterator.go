@@ -8,6 +8,33 @@
 
 package hashset
 
+import (
+	"iter"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+// All returns an iterator over the values of the set, in no particular order.
+//
+// Like GetEnumerator, the iterator panics with enumerator.ErrCollectionModified
+// if values are added or removed during iteration, unless the loop breaks
+// straight after the change.
+func (s *Set[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		version := s.version
+		for v := range s.set {
+			if !yield(v) {
+				return
+			}
+			s.checkVersion(version)
+		}
+	}
+}
+
+// Iter returns a channel of the values of the set.
+//
+// Deprecated: the goroutine feeding the channel leaks if the caller stops
+// receiving before the channel is closed. Use All instead.
 func (s Set[V]) Iter() <-chan V {
 	ch := make(chan V)
 	go func() {
@@ -18,3 +45,9 @@ func (s Set[V]) Iter() <-chan V {
 	}()
 	return ch
 }
+
+func (s *Set[V]) checkVersion(version int) {
+	if s.version != version {
+		panic(enumerator.ErrCollectionModified)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"testing"
 
 	. "github.com/glasket/datastructures/collection/set/hashset"
+	"github.com/glasket/datastructures/interfaces/enumerator"
 )
 
 func TestNewSet(t *testing.T) {
@@ -221,3 +222,60 @@ func TestSetJson(t *testing.T) {
 		t.Errorf("Expected Unmarshal to return %v, got %v", set, set2)
 	}
 }
+
+func TestSetAll(t *testing.T) {
+	set := NewFromSlice([]int{1, 2, 3, 4, 5})
+	values := make([]int, 0)
+	for v := range set.All() {
+		values = append(values, v)
+	}
+	sort.Ints(values)
+	if len(values) != 5 || values[0] != 1 || values[4] != 5 {
+		t.Errorf("Expected All to yield [1 2 3 4 5], got %v", values)
+	}
+
+	count := 0
+	for range set.All() {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("Expected All to stop after a break, got %d values", count)
+	}
+
+	for v := range set.All() {
+		set.Remove(v)
+		break
+	}
+	defer func() {
+		if r := recover(); r != enumerator.ErrCollectionModified {
+			t.Errorf("Expected All to panic with ErrCollectionModified, got %v", r)
+		}
+	}()
+	for v := range set.All() {
+		set.Remove(v)
+	}
+	t.Error("Expected All to panic after Remove")
+}
+
+func TestSetEnumeratorModified(t *testing.T) {
+	set := NewFromSlice([]int{1, 2, 3})
+	e := set.GetEnumerator()
+	e.Next()
+	set.Add(2)
+	set.Remove(4)
+	if !e.Next() {
+		t.Error("Expected no-op Add and Remove not to invalidate the enumerator")
+	}
+
+	defer func() {
+		if r := recover(); r != enumerator.ErrCollectionModified {
+			t.Errorf("Expected Next to panic with ErrCollectionModified, got %v", r)
+		}
+	}()
+	set.Add(4)
+	e.Next()
+	t.Error("Expected Next to panic after Add")
+}
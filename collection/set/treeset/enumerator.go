@@ -0,0 +1,43 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package treeset
+
+import (
+	"iter"
+
+	"github.com/glasket/datastructures/collection/internal/rbtree"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+// GetEnumerator returns an enumerator.IEnumerator over the set in ascending order.
+func (s *Set[V]) GetEnumerator() enumerator.IEnumerator[V] {
+	return enumerator.GetSliceEnumerable(s.Values()).GetEnumerator()
+}
+
+// All returns an iterator over the values of the set in ascending order.
+//
+// Values may be added or removed during iteration.
+func (s *Set[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		s.tree.AscendFrom(s.first(), func(n *rbtree.Node[V, struct{}]) bool {
+			return !s.above(n.Key) && yield(n.Key)
+		})
+	}
+}
+
+// Backward returns an iterator over the values of the set in descending order.
+//
+// Values may be added or removed during iteration.
+func (s *Set[V]) Backward() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		s.tree.DescendFrom(s.last(), func(n *rbtree.Node[V, struct{}]) bool {
+			return !s.below(n.Key) && yield(n.Key)
+		})
+	}
+}
@@ -0,0 +1,460 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package treeset
+
+import (
+	"fmt"
+
+	"github.com/glasket/datastructures/collection/internal/rbtree"
+	"github.com/glasket/datastructures/collection/set"
+	"golang.org/x/exp/constraints"
+)
+
+var _ set.ISet[int] = (*Set[int])(nil)
+
+var fil struct{} = struct{}{}
+
+// Set is a sorted set backed by a red-black tree.
+//
+// Values are kept in the order defined by the set's comparator, which must
+// return a negative number, zero, or a positive number when a is less than,
+// equal to, or greater than b.
+//
+// A set returned by HeadSet, TailSet, or SubSet is a view of a range of the
+// set it came from. The two share the same tree, so changes to either are
+// visible through the other.
+type Set[V comparable] struct {
+	tree   *rbtree.Tree[V, struct{}]
+	lo, hi bound[V]
+}
+
+// bound is one end of a range view. An unset bound leaves that end open.
+type bound[V any] struct {
+	value     V
+	inclusive bool
+	set       bool
+}
+
+// New creates an empty set ordered by cmp.
+func New[V comparable](cmp func(a, b V) int) *Set[V] {
+	return &Set[V]{
+		tree: rbtree.New[V, struct{}](cmp),
+	}
+}
+
+// NewOrdered creates an empty set of an ordered type, sorted ascending.
+func NewOrdered[V constraints.Ordered]() *Set[V] {
+	return New(rbtree.Compare[V])
+}
+
+// NewFromSlice creates a set ordered by cmp from a preexisting slice.
+//
+// Repeated values are ignored.
+func NewFromSlice[V comparable](slice []V, cmp func(a, b V) int) *Set[V] {
+	s := New(cmp)
+	for _, v := range slice {
+		s.Add(v)
+	}
+	return s
+}
+
+// Add adds a given value to the set.
+//
+// no-op if value is already present. Panics if the set is a range view and
+// value is outside its range.
+func (s *Set[V]) Add(value V) {
+	if !s.inRange(value) {
+		panic(fmt.Errorf("value %v is outside the range of the set", value))
+	}
+	s.tree.Put(value, fil)
+}
+
+// Remove removes the given value from the set.
+//
+// no-op if value is not present.
+func (s *Set[V]) Remove(value V) {
+	if s.inRange(value) {
+		s.tree.Delete(value)
+	}
+}
+
+// Clear deletes all values from the set.
+//
+// Clearing a range view only deletes the values in its range.
+func (s *Set[V]) Clear() {
+	if !s.lo.set && !s.hi.set {
+		s.tree.Clear()
+		return
+	}
+	for n := s.first(); n != nil; {
+		next := s.next(n)
+		s.tree.DeleteNode(n)
+		n = next
+	}
+}
+
+// Contains returns true if the given value is present in the set.
+func (s *Set[V]) Contains(value V) bool {
+	return s.inRange(value) && s.tree.Get(value) != nil
+}
+
+// String returns the string representation of the set.
+func (s *Set[V]) String() string {
+	return fmt.Sprintf("Set[%v]", s.Values())
+}
+
+// IsEmpty returns true if the set is empty.
+func (s *Set[V]) IsEmpty() bool {
+	return s.Count() == 0
+}
+
+// Count returns the number of values in the set.
+//
+// Counting a range view walks its range, so takes O(log n + k) for k values.
+func (s *Set[V]) Count() int {
+	if !s.lo.set && !s.hi.set {
+		return s.tree.Len()
+	}
+	count := 0
+	for n := s.first(); n != nil; n = s.next(n) {
+		count++
+	}
+	return count
+}
+
+// Values returns a slice of all values in the set, in ascending order.
+func (s *Set[V]) Values() []V {
+	values := make([]V, 0)
+	for n := s.first(); n != nil; n = s.next(n) {
+		values = append(values, n.Key)
+	}
+	return values
+}
+
+// First returns the lowest value in the set.
+//
+// Returns an error if the set is empty.
+func (s *Set[V]) First() (V, error) {
+	if n := s.first(); n != nil {
+		return n.Key, nil
+	}
+	return *new(V), fmt.Errorf("set is empty")
+}
+
+// Last returns the highest value in the set.
+//
+// Returns an error if the set is empty.
+func (s *Set[V]) Last() (V, error) {
+	if n := s.last(); n != nil {
+		return n.Key, nil
+	}
+	return *new(V), fmt.Errorf("set is empty")
+}
+
+// PollFirst removes and returns the lowest value in the set.
+//
+// Returns an error if the set is empty.
+func (s *Set[V]) PollFirst() (V, error) {
+	n := s.first()
+	if n == nil {
+		return *new(V), fmt.Errorf("set is empty")
+	}
+	s.tree.DeleteNode(n)
+	return n.Key, nil
+}
+
+// PollLast removes and returns the highest value in the set.
+//
+// Returns an error if the set is empty.
+func (s *Set[V]) PollLast() (V, error) {
+	n := s.last()
+	if n == nil {
+		return *new(V), fmt.Errorf("set is empty")
+	}
+	s.tree.DeleteNode(n)
+	return n.Key, nil
+}
+
+// Floor returns the highest value less than or equal to the given value.
+//
+// Returns an error if there is no such value.
+func (s *Set[V]) Floor(value V) (V, error) {
+	if n := s.atMost(s.tree.Floor(value)); n != nil {
+		return n.Key, nil
+	}
+	return *new(V), fmt.Errorf("no value <= %v", value)
+}
+
+// Ceiling returns the lowest value greater than or equal to the given value.
+//
+// Returns an error if there is no such value.
+func (s *Set[V]) Ceiling(value V) (V, error) {
+	if n := s.atLeast(s.tree.Ceiling(value)); n != nil {
+		return n.Key, nil
+	}
+	return *new(V), fmt.Errorf("no value >= %v", value)
+}
+
+// Lower returns the highest value strictly less than the given value.
+//
+// Returns an error if there is no such value.
+func (s *Set[V]) Lower(value V) (V, error) {
+	if n := s.atMost(s.tree.Lower(value)); n != nil {
+		return n.Key, nil
+	}
+	return *new(V), fmt.Errorf("no value < %v", value)
+}
+
+// Higher returns the lowest value strictly greater than the given value.
+//
+// Returns an error if there is no such value.
+func (s *Set[V]) Higher(value V) (V, error) {
+	if n := s.atLeast(s.tree.Higher(value)); n != nil {
+		return n.Key, nil
+	}
+	return *new(V), fmt.Errorf("no value > %v", value)
+}
+
+// HeadSet returns a view of the values less than to,
+// or less than or equal to if inclusive is true.
+//
+// The view shares the set's tree, so changes to either are visible through
+// the other. Adding a value outside the view's range panics.
+func (s *Set[V]) HeadSet(to V, inclusive bool) *Set[V] {
+	return s.view(bound[V]{}, bound[V]{to, inclusive, true})
+}
+
+// TailSet returns a view of the values greater than from,
+// or greater than or equal to if inclusive is true.
+//
+// The view shares the set's tree, so changes to either are visible through
+// the other. Adding a value outside the view's range panics.
+func (s *Set[V]) TailSet(from V, inclusive bool) *Set[V] {
+	return s.view(bound[V]{from, inclusive, true}, bound[V]{})
+}
+
+// SubSet returns a view of the values between from and to.
+// Each bound is included if its matching inclusive flag is true.
+//
+// The view shares the set's tree, so changes to either are visible through
+// the other. Adding a value outside the view's range panics.
+func (s *Set[V]) SubSet(from V, fromInclusive bool, to V, toInclusive bool) *Set[V] {
+	return s.view(bound[V]{from, fromInclusive, true}, bound[V]{to, toInclusive, true})
+}
+
+// view returns a set over the same tree limited to the range between lo and hi.
+// A view of a view keeps whichever bound is tighter at each end.
+func (s *Set[V]) view(lo, hi bound[V]) *Set[V] {
+	v := &Set[V]{tree: s.tree, lo: s.lo, hi: s.hi}
+	if lo.set && !v.below(lo.value) && (!v.lo.set || !lo.inclusive || s.tree.Cmp()(lo.value, v.lo.value) > 0) {
+		v.lo = lo
+	}
+	if hi.set && !v.above(hi.value) && (!v.hi.set || !hi.inclusive || s.tree.Cmp()(hi.value, v.hi.value) < 0) {
+		v.hi = hi
+	}
+	return v
+}
+
+// below returns true if value is less than the set's lower bound.
+func (s *Set[V]) below(value V) bool {
+	if !s.lo.set {
+		return false
+	}
+	c := s.tree.Cmp()(value, s.lo.value)
+	return c < 0 || (c == 0 && !s.lo.inclusive)
+}
+
+// above returns true if value is greater than the set's upper bound.
+func (s *Set[V]) above(value V) bool {
+	if !s.hi.set {
+		return false
+	}
+	c := s.tree.Cmp()(value, s.hi.value)
+	return c > 0 || (c == 0 && !s.hi.inclusive)
+}
+
+func (s *Set[V]) inRange(value V) bool {
+	return !s.below(value) && !s.above(value)
+}
+
+// first returns the node with the lowest value in range, or nil.
+func (s *Set[V]) first() *rbtree.Node[V, struct{}] {
+	n := s.tree.Min()
+	if s.lo.set && s.lo.inclusive {
+		n = s.tree.Ceiling(s.lo.value)
+	} else if s.lo.set {
+		n = s.tree.Higher(s.lo.value)
+	}
+	if n == nil || s.above(n.Key) {
+		return nil
+	}
+	return n
+}
+
+// last returns the node with the highest value in range, or nil.
+func (s *Set[V]) last() *rbtree.Node[V, struct{}] {
+	n := s.tree.Max()
+	if s.hi.set && s.hi.inclusive {
+		n = s.tree.Floor(s.hi.value)
+	} else if s.hi.set {
+		n = s.tree.Lower(s.hi.value)
+	}
+	if n == nil || s.below(n.Key) {
+		return nil
+	}
+	return n
+}
+
+// next returns the node following n if it is in range, or nil.
+func (s *Set[V]) next(n *rbtree.Node[V, struct{}]) *rbtree.Node[V, struct{}] {
+	if n = s.tree.Next(n); n == nil || s.above(n.Key) {
+		return nil
+	}
+	return n
+}
+
+// prev returns the node preceding n if it is in range, or nil.
+func (s *Set[V]) prev(n *rbtree.Node[V, struct{}]) *rbtree.Node[V, struct{}] {
+	if n = s.tree.Prev(n); n == nil || s.below(n.Key) {
+		return nil
+	}
+	return n
+}
+
+// atMost limits n, the result of searching down from some value, to the set's range.
+func (s *Set[V]) atMost(n *rbtree.Node[V, struct{}]) *rbtree.Node[V, struct{}] {
+	if n != nil && s.above(n.Key) {
+		return s.last()
+	}
+	if n == nil || s.below(n.Key) {
+		return nil
+	}
+	return n
+}
+
+// atLeast limits n, the result of searching up from some value, to the set's range.
+func (s *Set[V]) atLeast(n *rbtree.Node[V, struct{}]) *rbtree.Node[V, struct{}] {
+	if n != nil && s.below(n.Key) {
+		return s.first()
+	}
+	if n == nil || s.above(n.Key) {
+		return nil
+	}
+	return n
+}
+
+// Equals tests if two sets are equal.
+//
+// Two sets are equal if they contain the same values.
+func (s *Set[V]) Equals(other set.ISet[V]) bool {
+	if s.Count() != other.Count() {
+		return false
+	}
+
+	for n := s.first(); n != nil; n = s.next(n) {
+		if !other.Contains(n.Key) {
+			return false
+		}
+	}
+	return true
+}
+
+// [Union] returns a new set containing the values in both sets.
+//
+// The new set uses the comparator of the receiver.
+// Does not modify either set.
+//
+// [Union]: https://en.wikipedia.org/wiki/Union_(set_theory)
+func (s *Set[V]) Union(other set.ISet[V]) set.ISet[V] {
+	union := New(s.tree.Cmp())
+
+	for n := s.first(); n != nil; n = s.next(n) {
+		union.Add(n.Key)
+	}
+	for _, v := range other.Values() {
+		union.Add(v)
+	}
+
+	return union
+}
+
+// [Intersection] returns a new set containing the values in both sets.
+//
+// Does not modify either set.
+//
+// [Intersection]: https://en.wikipedia.org/wiki/Intersection_(set_theory)
+func (s *Set[V]) Intersection(other set.ISet[V]) set.ISet[V] {
+	intersection := New(s.tree.Cmp())
+
+	for n := s.first(); n != nil; n = s.next(n) {
+		if other.Contains(n.Key) {
+			intersection.Add(n.Key)
+		}
+	}
+
+	return intersection
+}
+
+// [Complement] returns a new set containing the values in the first set but not the second.
+//
+// Does not modify either set.
+//
+// [Complement]: https://en.wikipedia.org/wiki/Complement_(set_theory)
+func (s *Set[V]) Complement(other set.ISet[V]) set.ISet[V] {
+	complement := New(s.tree.Cmp())
+
+	for n := s.first(); n != nil; n = s.next(n) {
+		if !other.Contains(n.Key) {
+			complement.Add(n.Key)
+		}
+	}
+
+	return complement
+}
+
+// [RelativeComplement] returns a new set containing the values in the second set but not the first
+//
+// Does not modify either set.
+//
+// [RelativeComplement]: https://en.wikipedia.org/wiki/Complement_(set_theory)#Relative_complement
+func (s *Set[V]) RelativeComplement(other set.ISet[V]) set.ISet[V] {
+	return other.Complement(s)
+}
+
+// [SymmetricDifference] returns a new set containing the values in exactly one of the sets.
+//
+// Does not modify either set.
+//
+// [SymmetricDifference]: https://en.wikipedia.org/wiki/Symmetric_difference
+func (s *Set[V]) SymmetricDifference(other set.ISet[V]) set.ISet[V] {
+	union := s.Union(other)
+	intersection := s.Intersection(other)
+
+	return union.Complement(intersection)
+}
+
+// SubsetOf returns true if the first set is a subset of the second.
+func (s *Set[V]) SubsetOf(other set.ISet[V]) bool {
+	if s.Count() > other.Count() {
+		return false
+	}
+
+	for n := s.first(); n != nil; n = s.next(n) {
+		if !other.Contains(n.Key) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SupersetOf returns true if the first set is a superset of the second.
+func (s *Set[V]) SupersetOf(other set.ISet[V]) bool {
+	return other.SubsetOf(s)
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package treeset_test
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/glasket/datastructures/collection/set/hashset"
+	. "github.com/glasket/datastructures/collection/set/treeset"
+)
+
+func cmpInt(a, b int) int {
+	return a - b
+}
+
+func TestNewSet(t *testing.T) {
+	set := NewOrdered[int]()
+	if set.Count() != 0 {
+		t.Fatalf("Expected New to return a set of size 0, got %d", set.Count())
+	}
+	if _, err := set.First(); err == nil {
+		t.Error("Expected First to error on an empty set")
+	}
+	if _, err := set.PollLast(); err == nil {
+		t.Error("Expected PollLast to error on an empty set")
+	}
+}
+
+func TestSetOrdering(t *testing.T) {
+	set := NewFromSlice(rand.Perm(100), cmpInt)
+	set.Add(50)
+	if set.Count() != 100 {
+		t.Fatalf("Expected Add to not add a duplicate value, got %d", set.Count())
+	}
+	values := set.Values()
+	for i, v := range values {
+		if v != i {
+			t.Fatalf("Expected Values to be sorted, got %d at index %d", v, i)
+		}
+	}
+	enum := set.GetEnumerator()
+	for i := 0; enum.Next(); i++ {
+		if enum.Current() != i {
+			t.Fatalf("Expected enumerator to be sorted, got %d at index %d", enum.Current(), i)
+		}
+	}
+
+	desc := NewFromSlice([]string{"b", "c", "a"}, func(a, b string) int {
+		return strings.Compare(b, a)
+	})
+	if !reflect.DeepEqual(desc.Values(), []string{"c", "b", "a"}) {
+		t.Errorf("Expected a custom comparator to order the set, got %v", desc.Values())
+	}
+}
+
+func TestSetRemoveAndContains(t *testing.T) {
+	set := NewFromSlice([]int{1, 2, 3}, cmpInt)
+	set.Remove(2)
+	set.Remove(4)
+	if set.Count() != 2 || set.Contains(2) || !set.Contains(3) {
+		t.Errorf("Expected Set[1 3], got %v", set)
+	}
+	set.Clear()
+	if !set.IsEmpty() {
+		t.Errorf("Expected Clear to clear set, got %v", set)
+	}
+}
+
+func TestSetNavigation(t *testing.T) {
+	set := NewFromSlice([]int{10, 20, 30, 40}, cmpInt)
+	tests := []struct {
+		name  string
+		f     func(int) (int, error)
+		value int
+		want  int
+		err   bool
+	}{
+		{"Floor", set.Floor, 25, 20, false},
+		{"Floor", set.Floor, 20, 20, false},
+		{"Floor", set.Floor, 5, 0, true},
+		{"Ceiling", set.Ceiling, 25, 30, false},
+		{"Ceiling", set.Ceiling, 45, 0, true},
+		{"Lower", set.Lower, 20, 10, false},
+		{"Lower", set.Lower, 10, 0, true},
+		{"Higher", set.Higher, 20, 30, false},
+		{"Higher", set.Higher, 40, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.f(tt.value)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("%s(%d): expected %d (error %v), got %d (%v)", tt.name, tt.value, tt.want, tt.err, got, err)
+		}
+	}
+
+	if v, _ := set.First(); v != 10 {
+		t.Errorf("Expected First to return 10, got %d", v)
+	}
+	if v, _ := set.Last(); v != 40 {
+		t.Errorf("Expected Last to return 40, got %d", v)
+	}
+	if v, _ := set.PollFirst(); v != 10 || set.Contains(10) {
+		t.Errorf("Expected PollFirst to remove 10, got %d", v)
+	}
+	if v, _ := set.PollLast(); v != 40 || set.Contains(40) {
+		t.Errorf("Expected PollLast to remove 40, got %d", v)
+	}
+}
+
+func TestSetRanges(t *testing.T) {
+	set := NewFromSlice([]int{10, 20, 30, 40, 50}, cmpInt)
+	tests := []struct {
+		name string
+		got  *Set[int]
+		want []int
+	}{
+		{"HeadSet exclusive", set.HeadSet(30, false), []int{10, 20}},
+		{"HeadSet inclusive", set.HeadSet(30, true), []int{10, 20, 30}},
+		{"HeadSet between", set.HeadSet(35, false), []int{10, 20, 30}},
+		{"TailSet exclusive", set.TailSet(30, false), []int{40, 50}},
+		{"TailSet inclusive", set.TailSet(30, true), []int{30, 40, 50}},
+		{"SubSet exclusive", set.SubSet(20, false, 50, false), []int{30, 40}},
+		{"SubSet inclusive", set.SubSet(20, true, 50, true), []int{20, 30, 40, 50}},
+		{"SubSet empty", set.SubSet(31, true, 39, true), []int{}},
+		{"SubSet reversed", set.SubSet(40, true, 20, true), []int{}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got.Values(), tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, tt.got.Values())
+		}
+	}
+	if set.Count() != 5 {
+		t.Errorf("Expected range views to not modify the set, got %v", set)
+	}
+}
+
+func TestSetRangeViews(t *testing.T) {
+	set := NewFromSlice([]int{10, 20, 30, 40, 50}, cmpInt)
+	tail := set.TailSet(20, true)
+	tail.Add(60)
+	tail.Remove(30)
+	tail.Remove(10)
+	set.Remove(40)
+	if !reflect.DeepEqual(tail.Values(), []int{20, 50, 60}) || !reflect.DeepEqual(set.Values(), []int{10, 20, 50, 60}) {
+		t.Errorf("Expected changes to show through the view and the set, got %v and %v", tail, set)
+	}
+	if tail.Count() != 3 || tail.Contains(10) {
+		t.Errorf("Expected the view to hold only values in range, got %v", tail)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected Add outside the range to panic")
+			}
+		}()
+		tail.Add(5)
+	}()
+
+	head := set.HeadSet(50, false)
+	if v, _ := head.Floor(100); v != 20 {
+		t.Errorf("Expected Floor(100) of the view to be 20, got %d", v)
+	}
+	if v, _ := head.Ceiling(0); v != 10 {
+		t.Errorf("Expected Ceiling(0) of the view to be 10, got %d", v)
+	}
+	if _, err := head.Higher(20); err == nil {
+		t.Error("Expected Higher(20) of the view to fail")
+	}
+	if v, _ := head.Last(); v != 20 {
+		t.Errorf("Expected Last of the view to be 20, got %d", v)
+	}
+
+	// A view of a view keeps the tighter bounds
+	sub := tail.HeadSet(60, false).SubSet(0, true, 55, true)
+	if !reflect.DeepEqual(sub.Values(), []int{20, 50}) {
+		t.Errorf("Expected the nested view to be [20 50], got %v", sub)
+	}
+	values := make([]int, 0)
+	for v := range sub.Backward() {
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(values, []int{50, 20}) {
+		t.Errorf("Expected Backward over the view to be [50 20], got %v", values)
+	}
+
+	sub.Clear()
+	if !sub.IsEmpty() || !reflect.DeepEqual(set.Values(), []int{10, 60}) {
+		t.Errorf("Expected Clear to remove only the view's range, got %v", set)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	set1 := NewFromSlice([]int{1, 2, 3}, cmpInt)
+	set2 := hashset.NewFromSlice([]int{3, 4, 5})
+
+	if union := set1.Union(set2); !reflect.DeepEqual(union.Values(), []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected Union to return [1 2 3 4 5], got %v", union)
+	}
+	if intersection := set1.Intersection(set2); !reflect.DeepEqual(intersection.Values(), []int{3}) {
+		t.Errorf("Expected Intersection to return [3], got %v", intersection)
+	}
+	if complement := set1.Complement(set2); !reflect.DeepEqual(complement.Values(), []int{1, 2}) {
+		t.Errorf("Expected Complement to return [1 2], got %v", complement)
+	}
+	if difference := set1.SymmetricDifference(set2); !reflect.DeepEqual(difference.Values(), []int{1, 2, 4, 5}) {
+		t.Errorf("Expected SymmetricDifference to return [1 2 4 5], got %v", difference)
+	}
+	if !set1.Equals(hashset.NewFromSlice([]int{3, 2, 1})) {
+		t.Error("Expected Equals to return true, got false")
+	}
+	if !set1.SubsetOf(hashset.NewFromSlice([]int{1, 2, 3, 4})) || set1.SupersetOf(set2) {
+		t.Error("SubsetOf or SupersetOf returned an incorrect result")
+	}
+}
+
+func TestSetAll(t *testing.T) {
+	set := NewFromSlice([]int{5, 3, 1, 4, 2}, cmpInt)
+	values := make([]int, 0)
+	for v := range set.All() {
+		if v == 2 {
+			set.Remove(3)
+		}
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(values, []int{1, 2, 4, 5}) {
+		t.Errorf("Expected All to skip a value removed during iteration, got %v", values)
+	}
+
+	values = values[:0]
+	for v := range set.Backward() {
+		if v < 4 {
+			break
+		}
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(values, []int{5, 4}) {
+		t.Errorf("Expected Backward to yield [5 4] before breaking, got %v", values)
+	}
+}
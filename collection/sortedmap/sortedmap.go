@@ -0,0 +1,214 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sortedmap
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/glasket/datastructures/collection/internal/rbtree"
+	"github.com/glasket/datastructures/interfaces/enumerator"
+	"golang.org/x/exp/constraints"
+)
+
+var _ enumerator.IEnumerable[Entry[int, int]] = (*SortedMap[int, int])(nil)
+
+// Entry is a key/value pair of a SortedMap.
+type Entry[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// A SortedMap keeps its keys ordered by a comparator.
+//
+// The comparator must return a negative number, zero, or a positive number
+// when a is less than, equal to, or greater than b.
+type SortedMap[K any, V any] struct {
+	tree *rbtree.Tree[K, V]
+}
+
+// New creates an empty map ordered by cmp.
+func New[K any, V any](cmp func(a, b K) int) *SortedMap[K, V] {
+	return &SortedMap[K, V]{
+		tree: rbtree.New[K, V](cmp),
+	}
+}
+
+// NewOrdered creates an empty map with keys of an ordered type, sorted ascending.
+func NewOrdered[K constraints.Ordered, V any]() *SortedMap[K, V] {
+	return New[K, V](rbtree.Compare[K])
+}
+
+// Get returns the value assigned to the key, or an error if the key is not present.
+func (m *SortedMap[K, V]) Get(key K) (V, error) {
+	n := m.tree.Get(key)
+	if n == nil {
+		return *new(V), fmt.Errorf("key %v was not present", key)
+	}
+	return n.Value, nil
+}
+
+// Set assigns the value to the key.
+func (m *SortedMap[K, V]) Set(key K, value V) {
+	m.tree.Put(key, value)
+}
+
+// Remove removes the key and its assigned value.
+//
+// Returns an error if the key was not assigned.
+func (m *SortedMap[K, V]) Remove(key K) error {
+	if m.tree.Delete(key) == nil {
+		return fmt.Errorf("key %v was not present", key)
+	}
+	return nil
+}
+
+// Contains returns true if the key is present in the map.
+func (m *SortedMap[K, V]) Contains(key K) bool {
+	return m.tree.Get(key) != nil
+}
+
+// Count returns the number of keys in the map.
+func (m *SortedMap[K, V]) Count() int {
+	return m.tree.Len()
+}
+
+// IsEmpty returns true if the map is empty.
+func (m *SortedMap[K, V]) IsEmpty() bool {
+	return m.Count() == 0
+}
+
+// Clear removes all keys from the map.
+func (m *SortedMap[K, V]) Clear() {
+	m.tree.Clear()
+}
+
+// String returns the string representation of the map.
+func (m *SortedMap[K, V]) String() string {
+	return fmt.Sprintf("SortedMap%v", m.Values())
+}
+
+// Keys returns a slice of the keys in ascending order.
+func (m *SortedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Count())
+	for n := m.tree.Min(); n != nil; n = m.tree.Next(n) {
+		keys = append(keys, n.Key)
+	}
+	return keys
+}
+
+// Values returns a slice of the entries in ascending key order.
+func (m *SortedMap[K, V]) Values() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, m.Count())
+	for n := m.tree.Min(); n != nil; n = m.tree.Next(n) {
+		entries = append(entries, Entry[K, V]{n.Key, n.Value})
+	}
+	return entries
+}
+
+// GetEnumerator returns an enumerator.IEnumerator over the entries in ascending key order.
+func (m *SortedMap[K, V]) GetEnumerator() enumerator.IEnumerator[Entry[K, V]] {
+	return enumerator.GetSliceEnumerable(m.Values()).GetEnumerator()
+}
+
+// All returns an iterator over the keys and values of the map in ascending key order.
+//
+// Keys may be added or removed during iteration.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.tree.Ascend(func(n *rbtree.Node[K, V]) bool {
+			return yield(n.Key, n.Value)
+		})
+	}
+}
+
+// Backward returns an iterator over the keys and values of the map in descending key order.
+//
+// Keys may be added or removed during iteration.
+func (m *SortedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.tree.Descend(func(n *rbtree.Node[K, V]) bool {
+			return yield(n.Key, n.Value)
+		})
+	}
+}
+
+// First returns the entry with the lowest key.
+//
+// Returns an error if the map is empty.
+func (m *SortedMap[K, V]) First() (Entry[K, V], error) {
+	if n := m.tree.Min(); n != nil {
+		return Entry[K, V]{n.Key, n.Value}, nil
+	}
+	return Entry[K, V]{}, fmt.Errorf("map is empty")
+}
+
+// Last returns the entry with the highest key.
+//
+// Returns an error if the map is empty.
+func (m *SortedMap[K, V]) Last() (Entry[K, V], error) {
+	if n := m.tree.Max(); n != nil {
+		return Entry[K, V]{n.Key, n.Value}, nil
+	}
+	return Entry[K, V]{}, fmt.Errorf("map is empty")
+}
+
+// FloorKey returns the highest key less than or equal to the given key.
+//
+// Returns an error if there is no such key.
+func (m *SortedMap[K, V]) FloorKey(key K) (K, error) {
+	if n := m.tree.Floor(key); n != nil {
+		return n.Key, nil
+	}
+	return *new(K), fmt.Errorf("no key <= %v", key)
+}
+
+// CeilingKey returns the lowest key greater than or equal to the given key.
+//
+// Returns an error if there is no such key.
+func (m *SortedMap[K, V]) CeilingKey(key K) (K, error) {
+	if n := m.tree.Ceiling(key); n != nil {
+		return n.Key, nil
+	}
+	return *new(K), fmt.Errorf("no key >= %v", key)
+}
+
+// LowerKey returns the highest key strictly less than the given key.
+//
+// Returns an error if there is no such key.
+func (m *SortedMap[K, V]) LowerKey(key K) (K, error) {
+	if n := m.tree.Lower(key); n != nil {
+		return n.Key, nil
+	}
+	return *new(K), fmt.Errorf("no key < %v", key)
+}
+
+// HigherKey returns the lowest key strictly greater than the given key.
+//
+// Returns an error if there is no such key.
+func (m *SortedMap[K, V]) HigherKey(key K) (K, error) {
+	if n := m.tree.Higher(key); n != nil {
+		return n.Key, nil
+	}
+	return *new(K), fmt.Errorf("no key > %v", key)
+}
+
+// Range returns an enumerable of the entries with keys on the interval [lo, hi),
+// in ascending key order.
+//
+// The entries are copied when Range is called, so later changes to the map
+// are not reflected in the returned enumerable.
+func (m *SortedMap[K, V]) Range(lo, hi K) enumerator.IEnumerable[Entry[K, V]] {
+	cmp := m.tree.Cmp()
+	entries := make([]Entry[K, V], 0)
+	for n := m.tree.Ceiling(lo); n != nil && cmp(n.Key, hi) < 0; n = m.tree.Next(n) {
+		entries = append(entries, Entry[K, V]{n.Key, n.Value})
+	}
+	return enumerator.GetSliceEnumerable(entries)
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sortedmap_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/glasket/datastructures/collection/sortedmap"
+)
+
+func TestNewSortedMap(t *testing.T) {
+	m := NewOrdered[string, int]()
+	if m.Count() != 0 {
+		t.Fatalf("Expected New to return a map of size 0, got %d", m.Count())
+	}
+	if _, err := m.First(); err == nil {
+		t.Error("Expected First to error on an empty map")
+	}
+	if _, err := m.Last(); err == nil {
+		t.Error("Expected Last to error on an empty map")
+	}
+}
+
+func TestSortedMapOperations(t *testing.T) {
+	m := NewOrdered[string, int]()
+	m.Set("key", 1)
+	if v, err := m.Get("key"); v != 1 || err != nil {
+		t.Errorf("Expected Get to return 1, got %d (%v)", v, err)
+	}
+	m.Set("key", 2)
+	if v, _ := m.Get("key"); v != 2 || m.Count() != 1 {
+		t.Errorf("Expected Set to reassign the value, got %d with count %d", v, m.Count())
+	}
+	if !m.Contains("key") {
+		t.Error("Expected Contains to return true")
+	}
+	if err := m.Remove("key"); err != nil {
+		t.Errorf("Expected Remove to not error, got %v", err)
+	}
+	if err := m.Remove("key"); err == nil {
+		t.Error("Expected Remove to error on a missing key")
+	}
+	if _, err := m.Get("key"); err == nil {
+		t.Error("Expected Get to error on a missing key")
+	}
+}
+
+func TestSortedMapOrdering(t *testing.T) {
+	m := NewOrdered[int, int]()
+	for _, v := range rand.Perm(100) {
+		m.Set(v, v*v)
+	}
+	for i, k := range m.Keys() {
+		if k != i {
+			t.Fatalf("Expected Keys to be sorted, got %d at index %d", k, i)
+		}
+	}
+	enum := m.GetEnumerator()
+	for i := 0; enum.Next(); i++ {
+		if e := enum.Current(); e.Key != i || e.Value != i*i {
+			t.Fatalf("Expected entry {%d %d}, got %v", i, i*i, e)
+		}
+	}
+	if e, _ := m.First(); e.Key != 0 {
+		t.Errorf("Expected First to return key 0, got %v", e)
+	}
+	if e, _ := m.Last(); e.Key != 99 || e.Value != 99*99 {
+		t.Errorf("Expected Last to return {99 9801}, got %v", e)
+	}
+}
+
+func TestSortedMapNavigation(t *testing.T) {
+	m := NewOrdered[int, string]()
+	m.Set(10, "a")
+	m.Set(20, "b")
+	m.Set(30, "c")
+	if k, err := m.FloorKey(25); k != 20 || err != nil {
+		t.Errorf("Expected FloorKey(25) to return 20, got %d (%v)", k, err)
+	}
+	if k, err := m.CeilingKey(25); k != 30 || err != nil {
+		t.Errorf("Expected CeilingKey(25) to return 30, got %d (%v)", k, err)
+	}
+	if k, err := m.LowerKey(20); k != 10 || err != nil {
+		t.Errorf("Expected LowerKey(20) to return 10, got %d (%v)", k, err)
+	}
+	if k, err := m.HigherKey(20); k != 30 || err != nil {
+		t.Errorf("Expected HigherKey(20) to return 30, got %d (%v)", k, err)
+	}
+	if _, err := m.FloorKey(5); err == nil {
+		t.Error("Expected FloorKey to error below the lowest key")
+	}
+	if _, err := m.CeilingKey(35); err == nil {
+		t.Error("Expected CeilingKey to error above the highest key")
+	}
+}
+
+func TestSortedMapRange(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := New[time.Time, int](func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	for i := 0; i < 10; i++ {
+		m.Set(start.Add(time.Duration(i)*time.Hour), i)
+	}
+
+	r := m.Range(start.Add(2*time.Hour), start.Add(5*time.Hour))
+	got := make([]int, 0)
+	enum := r.GetEnumerator()
+	for enum.Next() {
+		got = append(got, enum.Current().Value)
+	}
+	if !reflect.DeepEqual(got, []int{2, 3, 4}) {
+		t.Errorf("Expected Range to return [2 3 4], got %v", got)
+	}
+
+	r = m.Range(start.Add(90*time.Minute), start.Add(150*time.Minute))
+	if len(r.Values()) != 1 || r.Values()[0].Value != 2 {
+		t.Errorf("Expected Range between keys to return [2], got %v", r.Values())
+	}
+
+	r = m.Range(start.Add(20*time.Hour), start.Add(30*time.Hour))
+	if len(r.Values()) != 0 {
+		t.Errorf("Expected Range past the last key to be empty, got %v", r.Values())
+	}
+}
+
+func TestSortedMapAll(t *testing.T) {
+	m := NewOrdered[int, string]()
+	m.Set(2, "b")
+	m.Set(1, "a")
+	m.Set(3, "c")
+
+	keys := make([]int, 0)
+	values := make([]string, 0)
+	for k, v := range m.All() {
+		if k == 1 {
+			m.Remove(1)
+			m.Set(4, "d")
+		}
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	if !reflect.DeepEqual(keys, []int{1, 2, 3, 4}) || !reflect.DeepEqual(values, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected All to yield [1 2 3 4] and [a b c d], got %v and %v", keys, values)
+	}
+
+	keys = keys[:0]
+	for k := range m.Backward() {
+		keys = append(keys, k)
+	}
+	if !reflect.DeepEqual(keys, []int{4, 3, 2}) {
+		t.Errorf("Expected Backward to yield [4 3 2], got %v", keys)
+	}
+}
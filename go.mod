@@ -1,6 +1,6 @@
 module github.com/glasket/datastructures
 
-go 1.20
+go 1.24
 
 require (
 	golang.org/x/exp v0.0.0-20230522175609-2e198f4a06a1
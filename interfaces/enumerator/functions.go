@@ -84,6 +84,40 @@ func Reduce[V any, R any](e IEnumerable[V], f func(R, V) R, initial R) R {
 	return result
 }
 
+// Scan returns a new enumerable with the running results of applying f to the elements in the enumerable.
+//
+// The first element is unchanged and each later element is f of the previous
+// result and that element, so Scan with addition gives running totals.
+func Scan[V any](e IEnumerable[V], f func(V, V) V) IEnumerable[V] {
+	enum := e.GetEnumerator()
+	results := make([]V, 0)
+	for enum.Next() {
+		if len(results) == 0 {
+			results = append(results, enum.Current())
+		} else {
+			results = append(results, f(results[len(results)-1], enum.Current()))
+		}
+	}
+	return GetSliceEnumerable(results)
+}
+
+// ExclusiveScan returns a new enumerable with the running results of applying f to the elements in the enumerable,
+// excluding each element from its own result.
+//
+// The first result is identity and each later result is f of the previous
+// result and the previous element, so ExclusiveScan with addition and 0 gives
+// the offset of each element.
+func ExclusiveScan[V any](e IEnumerable[V], f func(V, V) V, identity V) IEnumerable[V] {
+	enum := e.GetEnumerator()
+	results := make([]V, 0)
+	acc := identity
+	for enum.Next() {
+		results = append(results, acc)
+		acc = f(acc, enum.Current())
+	}
+	return GetSliceEnumerable(results)
+}
+
 type number interface {
 	constraints.Integer | constraints.Float | constraints.Complex
 }
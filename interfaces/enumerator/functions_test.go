@@ -10,6 +10,7 @@ package enumerator_test
 
 import (
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/glasket/datastructures/interfaces/enumerator"
@@ -118,6 +119,21 @@ func TestReduce(t *testing.T) {
 	}
 }
 
+func TestScan(t *testing.T) {
+	s := enumerator.GetSliceEnumerable([]int{3, 1, 4, 1, 5})
+	add := func(a, b int) int { return a + b }
+
+	if out := enumerator.Scan(s, add).Values(); !reflect.DeepEqual(out, []int{3, 4, 8, 9, 14}) {
+		t.Errorf("Expected Scan to return [3 4 8 9 14], got %v", out)
+	}
+	if out := enumerator.ExclusiveScan(s, add, 0).Values(); !reflect.DeepEqual(out, []int{0, 3, 4, 8, 9}) {
+		t.Errorf("Expected ExclusiveScan to return [0 3 4 8 9], got %v", out)
+	}
+	if out := enumerator.Scan(enumerator.GetSliceEnumerable([]int{}), add).Values(); len(out) != 0 {
+		t.Errorf("Expected Scan of an empty enumerable to be empty, got %v", out)
+	}
+}
+
 func TestRange(t *testing.T) {
 	// Test ascending range
 	s := enumerator.Range(0, 10)
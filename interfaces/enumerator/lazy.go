@@ -0,0 +1,211 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+import "golang.org/x/exp/constraints"
+
+// lazyEnumerable is an IEnumerable whose elements are computed on demand by
+// the enumerators it creates, rather than being stored in a slice.
+//
+// Each call to GetEnumerator starts a fresh pass over the source.
+type lazyEnumerable[V any] struct {
+	enumerator func() IEnumerator[V]
+}
+
+func (e *lazyEnumerable[V]) GetEnumerator() IEnumerator[V] {
+	return e.enumerator()
+}
+
+// Values computes every element and returns them as a slice.
+//
+// Values never returns for an infinite enumerable.
+func (e *lazyEnumerable[V]) Values() []V {
+	enum := e.enumerator()
+	values := make([]V, 0)
+	for enum.Next() {
+		values = append(values, enum.Current())
+	}
+	return values
+}
+
+// funcEnumerator is an IEnumerator that computes each element with next.
+//
+// next must keep returning false once it has returned false, until reset is called.
+type funcEnumerator[V any] struct {
+	next    func() (V, bool)
+	reset   func()
+	current V
+}
+
+func (e *funcEnumerator[V]) Current() V {
+	return e.current
+}
+
+func (e *funcEnumerator[V]) Next() bool {
+	v, ok := e.next()
+	if !ok {
+		e.current = *new(V)
+		return false
+	}
+	e.current = v
+	return true
+}
+
+func (e *funcEnumerator[V]) Reset() {
+	e.reset()
+	e.current = *new(V)
+}
+
+// LazyMap returns an enumerable that applies f to each element of e as it is enumerated.
+//
+// f is called again on every pass over the returned enumerable.
+func LazyMap[V any, R any](e IEnumerable[V], f func(V) R) IEnumerable[R] {
+	return &lazyEnumerable[R]{func() IEnumerator[R] {
+		src := e.GetEnumerator()
+		return &funcEnumerator[R]{
+			next: func() (R, bool) {
+				if !src.Next() {
+					return *new(R), false
+				}
+				return f(src.Current()), true
+			},
+			reset: src.Reset,
+		}
+	}}
+}
+
+// LazyFilter returns an enumerable of the elements of e for which f returns true,
+// tested as they are enumerated.
+func LazyFilter[V any](e IEnumerable[V], f func(V) bool) IEnumerable[V] {
+	return &lazyEnumerable[V]{func() IEnumerator[V] {
+		src := e.GetEnumerator()
+		return &funcEnumerator[V]{
+			next: func() (V, bool) {
+				for src.Next() {
+					if f(src.Current()) {
+						return src.Current(), true
+					}
+				}
+				return *new(V), false
+			},
+			reset: src.Reset,
+		}
+	}}
+}
+
+// LazyTake returns an enumerable of at most the first n elements of e.
+//
+// The source is not advanced past the nth element, which makes LazyTake
+// the way to bound an infinite enumerable.
+func LazyTake[V any](e IEnumerable[V], n int) IEnumerable[V] {
+	return &lazyEnumerable[V]{func() IEnumerator[V] {
+		src := e.GetEnumerator()
+		taken := 0
+		return &funcEnumerator[V]{
+			next: func() (V, bool) {
+				if taken >= n || !src.Next() {
+					return *new(V), false
+				}
+				taken += 1
+				return src.Current(), true
+			},
+			reset: func() {
+				src.Reset()
+				taken = 0
+			},
+		}
+	}}
+}
+
+// LazyTakeWhile returns an enumerable of the elements of e up to the first
+// element for which f returns false.
+func LazyTakeWhile[V any](e IEnumerable[V], f func(V) bool) IEnumerable[V] {
+	return &lazyEnumerable[V]{func() IEnumerator[V] {
+		src := e.GetEnumerator()
+		done := false
+		return &funcEnumerator[V]{
+			next: func() (V, bool) {
+				if done || !src.Next() || !f(src.Current()) {
+					done = true
+					return *new(V), false
+				}
+				return src.Current(), true
+			},
+			reset: func() {
+				src.Reset()
+				done = false
+			},
+		}
+	}}
+}
+
+// LazyRange returns an enumerable of integers on the interval [start, end),
+// computed as they are enumerated. It matches Range otherwise.
+func LazyRange[V constraints.Integer](start, end V) IEnumerable[V] {
+	if start == end {
+		return GetSliceEnumerable([]V{start})
+	}
+	f := add[V]
+	if end < start {
+		f = sub[V]
+	}
+	return &lazyEnumerable[V]{func() IEnumerator[V] {
+		i := start
+		return &funcEnumerator[V]{
+			next: func() (V, bool) {
+				if i == end {
+					return *new(V), false
+				}
+				v := i
+				i = f(i, 1)
+				return v, true
+			},
+			reset: func() {
+				i = start
+			},
+		}
+	}}
+}
+
+// Iterate returns an infinite enumerable of seed, f(seed), f(f(seed)), and so on.
+//
+// Combine with LazyTake or LazyTakeWhile to bound it.
+func Iterate[V any](seed V, f func(V) V) IEnumerable[V] {
+	return &lazyEnumerable[V]{func() IEnumerator[V] {
+		started := false
+		v := seed
+		return &funcEnumerator[V]{
+			next: func() (V, bool) {
+				if started {
+					v = f(v)
+				}
+				started = true
+				return v, true
+			},
+			reset: func() {
+				started = false
+				v = seed
+			},
+		}
+	}}
+}
+
+// Repeat returns an infinite enumerable of v.
+//
+// Combine with LazyTake or LazyTakeWhile to bound it.
+func Repeat[V any](v V) IEnumerable[V] {
+	return &lazyEnumerable[V]{func() IEnumerator[V] {
+		return &funcEnumerator[V]{
+			next: func() (V, bool) {
+				return v, true
+			},
+			reset: func() {},
+		}
+	}}
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+func TestLazyMap(t *testing.T) {
+	calls := 0
+	mapped := enumerator.LazyMap(enumerator.Range(0, PERM_SIZE), func(i int) int {
+		calls += 1
+		return i * i
+	})
+	if calls != 0 {
+		t.Fatalf("LazyMap should not call f before enumeration, called %d times", calls)
+	}
+	enum := mapped.GetEnumerator()
+	for i := 0; i < 10; i++ {
+		if !enum.Next() || enum.Current() != i*i {
+			t.Fatalf("LazyMap did not properly map element %d, got %d", i, enum.Current())
+		}
+	}
+	if calls != 10 {
+		t.Errorf("LazyMap should only call f for enumerated elements, called %d times", calls)
+	}
+	enum.Reset()
+	if !enum.Next() || enum.Current() != 0 {
+		t.Errorf("LazyMap did not restart after Reset, got %d", enum.Current())
+	}
+	if len(mapped.Values()) != PERM_SIZE {
+		t.Errorf("LazyMap did not map all elements, got %d", len(mapped.Values()))
+	}
+}
+
+func TestLazyFilter(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	filtered := enumerator.LazyFilter(s, func(i int) bool {
+		return i%2 == 0
+	})
+	if len(filtered.Values()) != PERM_SIZE/2 {
+		t.Errorf("LazyFilter did not filter out all odd elements, expected %d elements, got %d", PERM_SIZE/2, len(filtered.Values()))
+	}
+	if !enumerator.All(filtered, func(i int) bool { return i%2 == 0 }) {
+		t.Error("LazyFilter did not filter out all odd elements")
+	}
+}
+
+func TestLazyRange(t *testing.T) {
+	if !reflect.DeepEqual(enumerator.LazyRange(0, 10).Values(), enumerator.Range(0, 10).Values()) {
+		t.Errorf("LazyRange did not match Range for an ascending range, got %v", enumerator.LazyRange(0, 10).Values())
+	}
+	if !reflect.DeepEqual(enumerator.LazyRange[uint](10, 0).Values(), enumerator.Range[uint](10, 0).Values()) {
+		t.Errorf("LazyRange did not match Range for a descending range, got %v", enumerator.LazyRange[uint](10, 0).Values())
+	}
+	if !reflect.DeepEqual(enumerator.LazyRange(10, 10).Values(), []int{10}) {
+		t.Errorf("LazyRange did not match Range for a single value range, got %v", enumerator.LazyRange(10, 10).Values())
+	}
+}
+
+func TestLazyInfinite(t *testing.T) {
+	powers := enumerator.LazyTake(enumerator.Iterate(1, func(i int) int { return i * 2 }), 5)
+	if !reflect.DeepEqual(powers.Values(), []int{1, 2, 4, 8, 16}) {
+		t.Errorf("LazyTake of Iterate returned %v", powers.Values())
+	}
+	// Passes over the same enumerable are independent
+	if !reflect.DeepEqual(powers.Values(), []int{1, 2, 4, 8, 16}) {
+		t.Errorf("Second pass of LazyTake of Iterate returned %v", powers.Values())
+	}
+
+	small := enumerator.LazyTakeWhile(enumerator.Iterate(0, func(i int) int { return i + 3 }), func(i int) bool {
+		return i < 10
+	})
+	if !reflect.DeepEqual(small.Values(), []int{0, 3, 6, 9}) {
+		t.Errorf("LazyTakeWhile of Iterate returned %v", small.Values())
+	}
+
+	if enumerator.Count(enumerator.LazyTake(enumerator.Repeat("a"), 3), func(s string) bool { return s == "a" }) != 3 {
+		t.Error("LazyTake of Repeat did not return 3 elements")
+	}
+}
+
+func TestLazyComposition(t *testing.T) {
+	// Early termination over a huge range
+	pipeline := enumerator.LazyTake(
+		enumerator.LazyFilter(
+			enumerator.LazyMap(enumerator.LazyRange(0, 1_000_000_000), func(i int) int { return i * 3 }),
+			func(i int) bool { return i%2 == 0 },
+		), 4)
+	if !reflect.DeepEqual(pipeline.Values(), []int{0, 6, 12, 18}) {
+		t.Errorf("Lazy pipeline returned %v", pipeline.Values())
+	}
+
+	// Eager functions accept lazy enumerables and vice versa
+	eager := enumerator.Map(pipeline, func(i int) int { return i / 6 })
+	if enumerator.Sum(eager) != 6 {
+		t.Errorf("Eager Map over a lazy pipeline returned %v", eager.Values())
+	}
+	lazy := enumerator.LazyMap(enumerator.Filter(enumerator.Range(0, 6), func(i int) bool { return i > 2 }), func(i int) int { return -i })
+	if !reflect.DeepEqual(lazy.Values(), []int{-3, -4, -5}) {
+		t.Errorf("LazyMap over an eager Filter returned %v", lazy.Values())
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+// Monoid is an associative Combine function with an Identity element,
+// such that Combine(Identity, x) == Combine(x, Identity) == x.
+//
+// A Monoid is everything a parallel reduction needs: each partition can start
+// from Identity, and the partition results can be combined in any grouping.
+// Identity is shared by every partition, so Combine must return a new value
+// rather than modify either argument.
+type Monoid[R any] struct {
+	Identity R
+	Combine  func(R, R) R
+}
+
+// Fold combines the values from left to right, starting from m.Identity.
+//
+// Fold can be passed as the combiner of ParallelReduce.
+func (m Monoid[R]) Fold(values []R) R {
+	result := m.Identity
+	for _, v := range values {
+		result = m.Combine(result, v)
+	}
+	return result
+}
+
+// ParallelReduceMonoid maps each element of e with f and combines the results with m, in parallel.
+func ParallelReduceMonoid[V any, R any](e IEnumerable[V], f func(V) R, m Monoid[R]) R {
+	return ParallelReduce(e, func(acc R, v V) R {
+		return m.Combine(acc, f(v))
+	}, m.Fold, m.Identity)
+}
+
+// ParallelFold combines the elements of e with m, in parallel.
+func ParallelFold[V any](e IEnumerable[V], m Monoid[V]) V {
+	return ParallelReduce(e, m.Combine, m.Fold, m.Identity)
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var (
+	minMonoid = enumerator.Monoid[int]{Identity: math.MaxInt, Combine: func(a, b int) int {
+		if b < a {
+			return b
+		}
+		return a
+	}}
+	maxMonoid = enumerator.Monoid[int]{Identity: math.MinInt, Combine: func(a, b int) int {
+		if b > a {
+			return b
+		}
+		return a
+	}}
+	productMonoid = enumerator.Monoid[int]{Identity: 1, Combine: func(a, b int) int {
+		return a * b
+	}}
+	concatMonoid = enumerator.Monoid[string]{Identity: "", Combine: func(a, b string) string {
+		return a + b
+	}}
+)
+
+func TestParallelFold(t *testing.T) {
+	// Offset so that the zero value isn't a valid answer
+	s := enumerator.Map(enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE)), func(i int) int {
+		return i + 10
+	})
+
+	// 4 workers over 5 values gives fewer partitions than workers
+	small := enumerator.GetSliceEnumerable([]int{3, 2, 5, 1, 4})
+
+	for _, workers := range []int{1, 3, 4, 16} {
+		p := enumerator.Parallel(s).WithWorkers(workers)
+		if v := enumerator.ParallelFold[int](p, minMonoid); v != 10 {
+			t.Errorf("Expected min with %d workers to be 10, got %d", workers, v)
+		}
+		if v := enumerator.ParallelFold[int](p, maxMonoid); v != PERM_SIZE+9 {
+			t.Errorf("Expected max with %d workers to be %d, got %d", workers, PERM_SIZE+9, v)
+		}
+		if v := enumerator.ParallelFold[int](enumerator.Parallel(small).WithWorkers(workers), productMonoid); v != 120 {
+			t.Errorf("Expected product with %d workers to be 120, got %d", workers, v)
+		}
+		if v := enumerator.ParallelFold[int](enumerator.Parallel(small).WithWorkers(workers), minMonoid); v != 1 {
+			t.Errorf("Expected min of a small enumerable with %d workers to be 1, got %d", workers, v)
+		}
+	}
+
+	if v := enumerator.ParallelFold(enumerator.GetSliceEnumerable([]int{}), maxMonoid); v != math.MinInt {
+		t.Errorf("Expected max of an empty enumerable to be the identity, got %d", v)
+	}
+}
+
+func TestParallelReduceMonoid(t *testing.T) {
+	s := enumerator.Range(0, 100)
+	expected := strings.Join(enumerator.Map(s, strconv.Itoa).Values(), "")
+	for _, workers := range []int{1, 3, 7} {
+		v := enumerator.ParallelReduceMonoid[int](enumerator.Parallel(s).WithWorkers(workers), strconv.Itoa, concatMonoid)
+		if v != expected {
+			t.Errorf("Expected concat with %d workers to be %s, got %s", workers, expected, v)
+		}
+	}
+}
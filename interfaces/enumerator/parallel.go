@@ -9,205 +9,370 @@
 package enumerator
 
 import (
+	"context"
 	"runtime"
 	"sync"
 	"sync/atomic"
 
+	"github.com/glasket/datastructures/utils/mathutils"
 	"github.com/glasket/datastructures/utils/sliceutils"
 )
 
-var cc int = runtime.NumCPU()
+var cc atomic.Int64
 
-// SetConcurrency sets the number of goroutines to use for parallel operations.
+func init() {
+	cc.Store(int64(runtime.NumCPU()))
+}
+
+// SetConcurrency sets the default number of goroutines to use for parallel operations.
 // This will impact the number of slice chunks for parallel operations.
 //
+// Operations on an enumerable wrapped with Parallel use its options instead.
 // Defaults to runtime.NumCPU().
 func SetConcurrency(c int) {
-	cc = c
+	cc.Store(int64(c))
 }
 
 type parallelEnumerator[V any] struct {
 	partitions [][]V
+	offsets    []int // index of the first value of each partition
 	length     int
+	workers    int
+	opts       ParallelOptions
 }
 
 func asParallel[V any](e IEnumerable[V]) *parallelEnumerator[V] {
+	var opts ParallelOptions
+	if p, ok := e.(*ParallelEnumerable[V]); ok {
+		opts = p.opts
+	}
 	vals := e.Values()
-	return &parallelEnumerator[V]{
-		partitions: sliceutils.Chunk(vals, cc),
+	workers := opts.workers()
+	chunkSize := mathutils.IntDivCeil(len(vals), workers)
+	if opts.Strategy == StrategyDynamic {
+		chunkSize = mathutils.IntDivCeil(len(vals), workers*dynamicBatchesPerWorker)
+		if opts.MinChunkSize > 0 {
+			chunkSize = opts.MinChunkSize
+		}
+	}
+	if m := opts.minChunkSize(); chunkSize < m {
+		chunkSize = m
+	}
+
+	count := mathutils.IntDivCeil(len(vals), chunkSize)
+	pe := &parallelEnumerator[V]{
+		partitions: make([][]V, 0, count),
+		offsets:    make([]int, 0, count),
 		length:     len(vals),
+		workers:    workers,
+		opts:       opts,
+	}
+	for i := 0; i < len(vals); i += chunkSize {
+		end := i + chunkSize
+		if end > len(vals) {
+			end = len(vals)
+		}
+		pe.partitions = append(pe.partitions, vals[i:end])
+		pe.offsets = append(pe.offsets, i)
 	}
+	return pe
 }
 
 // ParallelEach calls f on each element of e in parallel.
 func ParallelEach[V any](e IEnumerable[V], f func(V)) {
+	ParallelEachCtx(context.Background(), e, f)
+}
+
+// ParallelEachCtx calls f on each element of e in parallel.
+//
+// If ctx is cancelled the partitions stop before their next element and
+// ctx.Err() is returned once all of them have stopped.
+func ParallelEachCtx[V any](ctx context.Context, e IEnumerable[V], f func(V)) error {
 	pe := asParallel(e)
-	var wg sync.WaitGroup
-	for _, partition := range pe.partitions {
-		wg.Add(1)
-		go func(p []V) {
-			defer wg.Done()
-			for _, v := range p {
-				f(v)
+	pe.run(func(_ int, p []V) {
+		for _, v := range p {
+			if isDone(ctx) {
+				return
 			}
-		}(partition)
-	}
-	wg.Wait()
+			f(v)
+		}
+	})
+	return ctx.Err()
 }
 
 // ParallelAll returns true if f returns true for all elements of e.
 //
 // ParallelAll short circuits on the first false return from f.
 func ParallelAll[V any](e IEnumerable[V], f func(V) bool) bool {
+	all, _ := ParallelAllCtx(context.Background(), e, f)
+	return all
+}
+
+// ParallelAllCtx returns true if f returns true for all elements of e.
+//
+// ParallelAllCtx short circuits on the first false return from f.
+// If ctx is cancelled before a false return is found, false and ctx.Err()
+// are returned.
+func ParallelAllCtx[V any](ctx context.Context, e IEnumerable[V], f func(V) bool) (bool, error) {
 	pe := asParallel(e)
-	quit := make(chan bool, cc)
-	defer close(quit)
-	var wg sync.WaitGroup
-	success := atomic.Bool{}
-	success.Store(true)
+	quit, cancel := context.WithCancel(ctx)
+	defer cancel()
+	failed := atomic.Bool{}
 
-	for _, partition := range pe.partitions {
-		wg.Add(1)
-		go func(p []V, success *atomic.Bool) {
-			defer wg.Done()
-			for _, v := range p {
-				select {
-				case <-quit:
-					return
-				default:
-					if !f(v) {
-						quit <- true
-						success.CompareAndSwap(true, false)
-						return
-					}
-				}
+	pe.run(func(_ int, p []V) {
+		for _, v := range p {
+			if isDone(quit) {
+				return
 			}
-		}(partition, &success)
-	}
+			if !f(v) {
+				failed.Store(true)
+				cancel()
+				return
+			}
+		}
+	})
 
-	wg.Wait()
-	return success.Load()
+	if failed.Load() {
+		return false, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // ParallelAny returns true if f returns true for any element of e.
 //
 // ParallelAny short circuits on the first true return from f.
 func ParallelAny[V any](e IEnumerable[V], f func(V) bool) bool {
+	found, _ := ParallelAnyCtx(context.Background(), e, f)
+	return found
+}
+
+// ParallelAnyCtx returns true if f returns true for any element of e.
+//
+// ParallelAnyCtx short circuits on the first true return from f.
+// If ctx is cancelled before a true return is found, false and ctx.Err()
+// are returned.
+func ParallelAnyCtx[V any](ctx context.Context, e IEnumerable[V], f func(V) bool) (bool, error) {
 	pe := asParallel(e)
-	quit := make(chan bool, cc)
-	defer close(quit)
-	var wg sync.WaitGroup
-	success := atomic.Bool{}
+	quit, cancel := context.WithCancel(ctx)
+	defer cancel()
+	found := atomic.Bool{}
 
-	for _, partition := range pe.partitions {
-		wg.Add(1)
-		go func(p []V, success *atomic.Bool) {
-			defer wg.Done()
-			for _, v := range p {
-				select {
-				case <-quit:
-					return
-				default:
-					if f(v) {
-						quit <- true
-						success.CompareAndSwap(false, true)
-						return
-					}
-				}
+	pe.run(func(_ int, p []V) {
+		for _, v := range p {
+			if isDone(quit) {
+				return
 			}
-		}(partition, &success)
-	}
+			if f(v) {
+				found.Store(true)
+				cancel()
+				return
+			}
+		}
+	})
 
-	wg.Wait()
-	return success.Load()
+	if found.Load() {
+		return true, nil
+	}
+	return false, ctx.Err()
 }
 
 // ParallelCount returns the number of elements of e for which f returns true.
 func ParallelCount[V any](e IEnumerable[V], f func(V) bool) int {
+	count, _ := ParallelCountCtx(context.Background(), e, f)
+	return count
+}
+
+// ParallelCountCtx returns the number of elements of e for which f returns true.
+//
+// If ctx is cancelled, 0 and ctx.Err() are returned.
+func ParallelCountCtx[V any](ctx context.Context, e IEnumerable[V], f func(V) bool) (int, error) {
 	pe := asParallel(e)
 	count := atomic.Int64{}
-	var wg sync.WaitGroup
 
-	for _, partition := range pe.partitions {
-		wg.Add(1)
-		go func(p []V, count *atomic.Int64) {
-			defer wg.Done()
-			_count := 0
-			for _, v := range p {
-				if f(v) {
-					_count += 1
-				}
+	pe.run(func(_ int, p []V) {
+		_count := 0
+		for _, v := range p {
+			if isDone(ctx) {
+				return
 			}
-			count.Add(int64(_count))
-		}(partition, &count)
-	}
+			if f(v) {
+				_count += 1
+			}
+		}
+		count.Add(int64(_count))
+	})
 
-	wg.Wait()
-	return int(count.Load())
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return int(count.Load()), nil
 }
 
 // ParallelMap returns a new IEnumerable with the results of applying f to each element of e.
 //
 // ParallelMap preserves order.
 func ParallelMap[V any, R any](e IEnumerable[V], f func(V) R) IEnumerable[R] {
+	result, _ := ParallelMapCtx(context.Background(), e, f)
+	return result
+}
+
+// ParallelMapCtx returns a new IEnumerable with the results of applying f to each element of e.
+//
+// ParallelMapCtx preserves order. If ctx is cancelled, nil and ctx.Err() are returned.
+func ParallelMapCtx[V any, R any](ctx context.Context, e IEnumerable[V], f func(V) R) (IEnumerable[R], error) {
 	pe := asParallel(e)
 	result := make([]R, pe.length)
-	var wg sync.WaitGroup
 
-	chunkedRes := sliceutils.Chunk(result, cc)
-	for idx, partition := range pe.partitions {
-		wg.Add(1)
-		go func(p []V, r []R) {
-			defer wg.Done()
-			for i, v := range p {
-				r[i] = f(v)
+	pe.run(func(idx int, p []V) {
+		r := result[pe.offsets[idx]:]
+		for i, v := range p {
+			if isDone(ctx) {
+				return
 			}
-		}(partition, chunkedRes[idx])
+			r[i] = f(v)
+		}
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	wg.Wait()
-	return GetSliceEnumerable(result)
+	return GetSliceEnumerable(result), nil
 }
 
 // ParallelFilter returns a new IEnumerable with the elements of e for which f returns true.
 //
 // ParallelFilter preserves order.
 func ParallelFilter[V any](e IEnumerable[V], f func(V) bool) IEnumerable[V] {
+	result, _ := ParallelFilterCtx(context.Background(), e, f)
+	return result
+}
+
+// ParallelFilterCtx returns a new IEnumerable with the elements of e for which f returns true.
+//
+// ParallelFilterCtx preserves order. If ctx is cancelled, nil and ctx.Err() are returned.
+func ParallelFilterCtx[V any](ctx context.Context, e IEnumerable[V], f func(V) bool) (IEnumerable[V], error) {
 	pe := asParallel(e)
-	var wg sync.WaitGroup
-	chunkedRes := make([][]V, cc)
+	chunkedRes := make([][]V, len(pe.partitions))
 
-	for idx, partition := range pe.partitions {
-		wg.Add(1)
-		go func(p []V, r *[]V) {
-			defer wg.Done()
-			for _, v := range p {
-				if f(v) {
-					*r = append(*r, v)
-				}
+	pe.run(func(idx int, p []V) {
+		r := &chunkedRes[idx]
+		for _, v := range p {
+			if isDone(ctx) {
+				return
 			}
-		}(partition, &chunkedRes[idx])
+			if f(v) {
+				*r = append(*r, v)
+			}
+		}
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	wg.Wait()
-	return GetSliceEnumerable(sliceutils.Join(chunkedRes))
+	return GetSliceEnumerable(sliceutils.Join(chunkedRes)), nil
 }
 
 // ParallelReduce returns a single value by applying the given function f to each element in the enumerable.
-// The combiner function is used to combine the results of each partition of the enumerable.
+// The combiner function is used to combine the results of each partition of the enumerable, in order.
+//
+// Every partition starts from initial, so initial must be an identity for f
+// and the combiner, such as 0 for a sum or 1 for a product. If e is empty,
+// initial is returned without calling the combiner. See Monoid for a typed
+// identity and combiner pair.
+//
+// initial is shared by every partition, so f must not modify it in place. Use
+// ParallelReduceFunc to give each partition its own map, slice or pointer.
 func ParallelReduce[V any, R any](e IEnumerable[V], f func(R, V) R, combiner func([]R) R, initial R) R {
+	return ParallelReduceFunc(e, f, combiner, func() R { return initial })
+}
+
+// ParallelReduceFunc is ParallelReduce with each partition starting from a new value returned by seed.
+//
+// seed is called once per partition, or once if e is empty, so f may modify
+// the accumulator in place, such as counting into a map.
+func ParallelReduceFunc[V any, R any](e IEnumerable[V], f func(R, V) R, combiner func([]R) R, seed func() R) R {
+	result, _ := ParallelReduceCtx(context.Background(), e, f, combiner, seed)
+	return result
+}
+
+// ParallelReduceCtx returns a single value by applying the given function f to each element in the enumerable.
+// The combiner function is used to combine the results of each partition of the enumerable, in order.
+//
+// seed is used as in ParallelReduceFunc. If ctx is cancelled, the combiner is
+// not called and the zero value and ctx.Err() are returned.
+func ParallelReduceCtx[V any, R any](ctx context.Context, e IEnumerable[V], f func(R, V) R, combiner func([]R) R, seed func() R) (R, error) {
 	pe := asParallel(e)
+	chunkedRes := make([]R, len(pe.partitions))
+
+	pe.run(func(idx int, p []V) {
+		r := seed()
+		for i := range p {
+			if isDone(ctx) {
+				return
+			}
+			r = f(r, p[i])
+		}
+		chunkedRes[idx] = r
+	})
+
+	if err := ctx.Err(); err != nil {
+		return *new(R), err
+	}
+	if len(chunkedRes) == 0 {
+		return seed(), nil
+	}
+	return combiner(chunkedRes), nil
+}
+
+// run calls f on each partition and waits for all of them to return.
+//
+// With StrategyStatic each partition gets its own goroutine. With
+// StrategyDynamic up to pe.workers goroutines take partitions in order from a
+// shared cursor until none are left.
+func (pe *parallelEnumerator[V]) run(f func(idx int, p []V)) {
 	var wg sync.WaitGroup
-	chunkedRes := make([]R, cc)
+	if pe.opts.Strategy == StrategyDynamic {
+		var cursor atomic.Int64
+		workers := pe.workers
+		if workers > len(pe.partitions) {
+			workers = len(pe.partitions)
+		}
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					idx := int(cursor.Add(1) - 1)
+					if idx >= len(pe.partitions) {
+						return
+					}
+					f(idx, pe.partitions[idx])
+				}
+			}()
+		}
+		wg.Wait()
+		return
+	}
 
 	for idx, partition := range pe.partitions {
 		wg.Add(1)
-		go func(p []V, idx int) {
+		go func(idx int, p []V) {
 			defer wg.Done()
-			r := &chunkedRes[idx]
-			for i := range p {
-				*r = f(*r, p[i])
-			}
-		}(partition, idx)
+			f(idx, p)
+		}(idx, partition)
 	}
 	wg.Wait()
-	return combiner(chunkedRes)
+}
+
+// isDone reports whether ctx has been cancelled without blocking.
+func isDone(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
 }
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// ErrorMode controls how the ...Err parallel operations handle errors returned by their callbacks.
+type ErrorMode int
+
+const (
+	// FailFast cancels the remaining partitions on the first error and returns that error.
+	FailFast ErrorMode = iota
+	// CollectAll processes every element and returns all errors combined with errors.Join,
+	// in partition order.
+	CollectAll
+)
+
+// PanicError is returned by the ...Err parallel operations when a callback panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic in parallel callback: %v", e.Value)
+}
+
+// Unwrap returns Value if it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// ParallelEachErr calls f on each element of e in parallel.
+//
+// Errors and panics from f are handled according to mode. If ctx is cancelled
+// the partitions stop before their next element and ctx.Err() is included in
+// the returned error.
+func ParallelEachErr[V any](ctx context.Context, e IEnumerable[V], f func(V) error, mode ErrorMode) error {
+	pe := asParallel(e)
+	return runErr(ctx, pe, mode, func(_, _ int, v V) error {
+		return f(v)
+	})
+}
+
+// ParallelMapErr returns a new IEnumerable with the results of applying f to each element of e.
+//
+// ParallelMapErr preserves order. Errors and panics from f are handled
+// according to mode. If any error occurs, nil and the error are returned.
+func ParallelMapErr[V any, R any](ctx context.Context, e IEnumerable[V], f func(V) (R, error), mode ErrorMode) (IEnumerable[R], error) {
+	pe := asParallel(e)
+	result := make([]R, pe.length)
+
+	err := runErr(ctx, pe, mode, func(idx, i int, v V) error {
+		r, err := f(v)
+		if err != nil {
+			return err
+		}
+		result[pe.offsets[idx]+i] = r
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return GetSliceEnumerable(result), nil
+}
+
+// ParallelReduceErr returns a single value by applying the given function f to each element in the enumerable.
+// The combiner function is used to combine the results of each partition of the enumerable, in order.
+//
+// seed is used as in ParallelReduceFunc. Errors and panics from f are handled
+// according to mode. If any error occurs, the combiner is not called and the
+// zero value and the error are returned.
+func ParallelReduceErr[V any, R any](ctx context.Context, e IEnumerable[V], f func(R, V) (R, error), combiner func([]R) R, seed func() R, mode ErrorMode) (R, error) {
+	pe := asParallel(e)
+	chunkedRes := make([]R, len(pe.partitions))
+	for i := range chunkedRes {
+		chunkedRes[i] = seed()
+	}
+
+	err := runErr(ctx, pe, mode, func(idx, _ int, v V) error {
+		r, err := f(chunkedRes[idx], v)
+		if err != nil {
+			return err
+		}
+		chunkedRes[idx] = r
+		return nil
+	})
+
+	if err != nil {
+		return *new(R), err
+	}
+	if len(chunkedRes) == 0 {
+		return seed(), nil
+	}
+	return combiner(chunkedRes), nil
+}
+
+// runErr calls f with the partition index, element index and value of every element of pe.
+//
+// In FailFast mode the first error cancels the other partitions and is returned.
+// In CollectAll mode every error is kept and they are joined in partition order.
+func runErr[V any](parent context.Context, pe *parallelEnumerator[V], mode ErrorMode, f func(idx, i int, v V) error) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	var once sync.Once
+	var first error
+	errs := make([][]error, len(pe.partitions))
+
+	pe.run(func(idx int, p []V) {
+		for i, v := range p {
+			if isDone(ctx) {
+				return
+			}
+			err := callErr(f, idx, i, v)
+			if err == nil {
+				continue
+			}
+			if mode == CollectAll {
+				errs[idx] = append(errs[idx], err)
+				continue
+			}
+			once.Do(func() {
+				first = err
+				cancel()
+			})
+			return
+		}
+	})
+
+	if mode == CollectAll {
+		joined := make([]error, 0)
+		for _, pErrs := range errs {
+			joined = append(joined, pErrs...)
+		}
+		joined = append(joined, parent.Err())
+		return errors.Join(joined...)
+	}
+	if first != nil {
+		return first
+	}
+	return parent.Err()
+}
+
+// callErr calls f, converting a panic into a *PanicError.
+func callErr[V any](f func(idx, i int, v V) error, idx, i int, v V) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return f(idx, i, v)
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+var errOdd = errors.New("odd value")
+
+func TestParallelEachErr(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+
+	calls := atomic.Int64{}
+	err := enumerator.ParallelEachErr(context.Background(), s, func(i int) error {
+		calls.Add(1)
+		return nil
+	}, enumerator.FailFast)
+	if err != nil || calls.Load() != int64(PERM_SIZE) {
+		t.Errorf("Expected ParallelEachErr to visit %d elements without error, got %d and %v", PERM_SIZE, calls.Load(), err)
+	}
+
+	err = enumerator.ParallelEachErr(context.Background(), s, func(i int) error {
+		if i == 5 {
+			return errOdd
+		}
+		return nil
+	}, enumerator.FailFast)
+	if !errors.Is(err, errOdd) {
+		t.Errorf("Expected ParallelEachErr to return errOdd, got %v", err)
+	}
+}
+
+func TestParallelEachErrCollectAll(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+
+	err := enumerator.ParallelEachErr(context.Background(), s, func(i int) error {
+		if i%2 != 0 {
+			return fmt.Errorf("%d: %w", i, errOdd)
+		}
+		return nil
+	}, enumerator.CollectAll)
+	if !errors.Is(err, errOdd) {
+		t.Fatalf("Expected ParallelEachErr to return errOdd, got %v", err)
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(joined.Unwrap()) != PERM_SIZE/2 {
+		t.Errorf("Expected ParallelEachErr to collect %d errors", PERM_SIZE/2)
+	}
+}
+
+func TestParallelMapErr(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+
+	out, err := enumerator.ParallelMapErr(context.Background(), s, func(i int) (string, error) {
+		return fmt.Sprint(i), nil
+	}, enumerator.FailFast)
+	if err != nil {
+		t.Fatalf("Expected ParallelMapErr to succeed, got %v", err)
+	}
+	for i, v := range out.Values() {
+		if v != fmt.Sprint(s.Values()[i]) {
+			t.Errorf("Expected ParallelMapErr to return %d at index %d, got %s", s.Values()[i], i, v)
+		}
+	}
+
+	ints, err := enumerator.ParallelMapErr(context.Background(), s, func(i int) (int, error) {
+		if i == PERM_SIZE-1 {
+			return 0, errOdd
+		}
+		return i, nil
+	}, enumerator.FailFast)
+	if ints != nil || !errors.Is(err, errOdd) {
+		t.Errorf("Expected ParallelMapErr to return nil and errOdd, got %v and %v", ints, err)
+	}
+}
+
+func TestParallelReduceErr(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	sum := func(r []int) int {
+		total := 0
+		for _, v := range r {
+			total += v
+		}
+		return total
+	}
+
+	out, err := enumerator.ParallelReduceErr(context.Background(), s, func(acc, i int) (int, error) {
+		return acc + i, nil
+	}, sum, func() int { return 0 }, enumerator.FailFast)
+	if expected := PERM_SIZE * (PERM_SIZE - 1) / 2; err != nil || out != expected {
+		t.Errorf("Expected ParallelReduceErr to return %d, got %d and %v", expected, out, err)
+	}
+
+	_, err = enumerator.ParallelReduceErr(context.Background(), s, func(acc, i int) (int, error) {
+		if i == 0 {
+			return 0, errOdd
+		}
+		return acc + i, nil
+	}, sum, func() int { return 0 }, enumerator.FailFast)
+	if !errors.Is(err, errOdd) {
+		t.Errorf("Expected ParallelReduceErr to return errOdd, got %v", err)
+	}
+}
+
+func TestParallelErrRecoversPanics(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+
+	err := enumerator.ParallelEachErr(context.Background(), s, func(i int) error {
+		if i == 5 {
+			panic(errOdd)
+		}
+		return nil
+	}, enumerator.FailFast)
+	var pe *enumerator.PanicError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected ParallelEachErr to return a PanicError, got %v", err)
+	}
+	if !errors.Is(err, errOdd) || len(pe.Stack) == 0 {
+		t.Errorf("Expected the PanicError to wrap errOdd and carry a stack, got %v", pe)
+	}
+
+	_, err = enumerator.ParallelMapErr(context.Background(), s, func(i int) (int, error) {
+		if i%2 == 0 {
+			panic("even")
+		}
+		return i, nil
+	}, enumerator.CollectAll)
+	if !errors.As(err, &pe) || pe.Value != "even" {
+		t.Errorf("Expected ParallelMapErr to return a PanicError, got %v", err)
+	}
+}
+
+func TestParallelErrCancelled(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, mode := range []enumerator.ErrorMode{enumerator.FailFast, enumerator.CollectAll} {
+		err := enumerator.ParallelEachErr(ctx, s, func(i int) error {
+			t.Error("Expected no calls with a cancelled context")
+			return nil
+		}, mode)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected ParallelEachErr to return context.Canceled, got %v", err)
+		}
+	}
+}
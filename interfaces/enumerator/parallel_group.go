@@ -0,0 +1,87 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+import "sync"
+
+// ParallelGroupBy returns a map of each key returned by key to the elements of e with that key.
+//
+// Each partition is grouped in parallel and the groups are then merged in
+// parallel. The elements of each group keep their order in e.
+func ParallelGroupBy[V any, K comparable](e IEnumerable[V], key func(V) K) map[K][]V {
+	pe := asParallel(e)
+	groups := make([]map[K][]V, len(pe.partitions))
+	pe.run(func(idx int, p []V) {
+		g := make(map[K][]V)
+		for _, v := range p {
+			k := key(v)
+			g[k] = append(g[k], v)
+		}
+		groups[idx] = g
+	})
+
+	return mergeMaps(groups, func(dst, src map[K][]V) {
+		for k, vs := range src {
+			dst[k] = append(dst[k], vs...)
+		}
+	})
+}
+
+// ParallelToMap returns a map of the keys and values returned by key and value for each element of e.
+//
+// When more than one element has the same key, conflict is called with the
+// value of the earlier element and the value of the later element, and its
+// result is kept. Each partition is mapped in parallel and the maps are then
+// merged in parallel.
+func ParallelToMap[V any, K comparable, U any](e IEnumerable[V], key func(V) K, value func(V) U, conflict func(existing, incoming U) U) map[K]U {
+	pe := asParallel(e)
+	maps := make([]map[K]U, len(pe.partitions))
+	pe.run(func(idx int, p []V) {
+		m := make(map[K]U)
+		for _, v := range p {
+			k, u := key(v), value(v)
+			if existing, ok := m[k]; ok {
+				u = conflict(existing, u)
+			}
+			m[k] = u
+		}
+		maps[idx] = m
+	})
+
+	return mergeMaps(maps, func(dst, src map[K]U) {
+		for k, u := range src {
+			if existing, ok := dst[k]; ok {
+				u = conflict(existing, u)
+			}
+			dst[k] = u
+		}
+	})
+}
+
+// mergeMaps merges the maps pairwise in parallel, always merging a later map
+// into an earlier one, and returns the result.
+//
+// Returns an empty map if there are no maps.
+func mergeMaps[M ~map[K]U, K comparable, U any](maps []M, merge func(dst, src M)) M {
+	if len(maps) == 0 {
+		return make(M)
+	}
+	for stride := 1; stride < len(maps); stride *= 2 {
+		var wg sync.WaitGroup
+		for i := 0; i+stride < len(maps); i += 2 * stride {
+			wg.Add(1)
+			go func(dst, src M) {
+				defer wg.Done()
+				merge(dst, src)
+			}(maps[i], maps[i+stride])
+		}
+		wg.Wait()
+	}
+	return maps[0]
+}
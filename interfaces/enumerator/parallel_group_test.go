@@ -0,0 +1,61 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+func TestParallelGroupBy(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	mod := func(i int) int { return i % 7 }
+
+	expected := make(map[int][]int)
+	for _, v := range s.Values() {
+		expected[mod(v)] = append(expected[mod(v)], v)
+	}
+	for _, workers := range []int{1, 2, 3, 8} {
+		groups := enumerator.ParallelGroupBy[int](enumerator.Parallel(s).WithWorkers(workers), mod)
+		if !reflect.DeepEqual(groups, expected) {
+			t.Errorf("Expected ParallelGroupBy with %d workers to return %v, got %v", workers, expected, groups)
+		}
+	}
+
+	empty := enumerator.ParallelGroupBy(enumerator.GetSliceEnumerable([]int{}), mod)
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("Expected ParallelGroupBy of an empty enumerable to return an empty map, got %v", empty)
+	}
+}
+
+func TestParallelToMap(t *testing.T) {
+	s := enumerator.GetSliceEnumerable([]string{"apple", "avocado", "banana", "blueberry", "cherry", "apricot"})
+	first := func(w string) byte { return w[0] }
+	length := func(w string) int { return len(w) }
+
+	for _, workers := range []int{1, 2, 4} {
+		p := enumerator.Parallel(s).WithWorkers(workers)
+		sums := enumerator.ParallelToMap[string](p, first, length, func(existing, incoming int) int {
+			return existing + incoming
+		})
+		if !reflect.DeepEqual(sums, map[byte]int{'a': 19, 'b': 15, 'c': 6}) {
+			t.Errorf("Expected ParallelToMap with %d workers to sum lengths, got %v", workers, sums)
+		}
+
+		latest := enumerator.ParallelToMap[string](p, first, func(w string) string { return w }, func(existing, incoming string) string {
+			return incoming
+		})
+		if !reflect.DeepEqual(latest, map[byte]string{'a': "apricot", 'b': "blueberry", 'c': "cherry"}) {
+			t.Errorf("Expected ParallelToMap with %d workers to keep the last value, got %v", workers, latest)
+		}
+	}
+}
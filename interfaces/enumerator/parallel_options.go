@@ -0,0 +1,126 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+// Strategy selects how a parallel operation divides work between its goroutines.
+type Strategy int
+
+const (
+	// StrategyStatic splits the values into one contiguous partition per worker.
+	StrategyStatic Strategy = iota
+	// StrategyDynamic splits the values into small batches which the workers
+	// pull from a shared cursor, so no worker sits idle while another is still
+	// working through an expensive partition.
+	//
+	// Use it when the cost of the callback varies a lot between values.
+	StrategyDynamic
+)
+
+// dynamicBatchesPerWorker is the number of batches per worker used by
+// StrategyDynamic when no MinChunkSize is given.
+const dynamicBatchesPerWorker = 8
+
+// ParallelOptions configures a single parallel operation.
+//
+// The zero value uses the package default set by SetConcurrency.
+type ParallelOptions struct {
+	// Workers is the maximum number of goroutines to use.
+	// Values <= 0 use the package default.
+	Workers int
+	// MinChunkSize is the minimum number of values given to each goroutine,
+	// so small inputs are not spread over more goroutines than they are worth.
+	// Values <= 0 are treated as 1.
+	//
+	// With StrategyDynamic this is the size of each batch, and values <= 0
+	// pick a size giving several batches per worker.
+	MinChunkSize int
+	// Strategy is the scheduling strategy.
+	Strategy Strategy
+}
+
+var _ IEnumerable[int] = (*ParallelEnumerable[int])(nil)
+
+// ParallelEnumerable wraps an IEnumerable with the options used when it is
+// passed to a parallel operation.
+//
+// The With methods return a modified copy, so a configured ParallelEnumerable
+// can be shared and reused.
+type ParallelEnumerable[V any] struct {
+	source IEnumerable[V]
+	opts   ParallelOptions
+}
+
+// Parallel wraps e so that the parallel operations it is passed to use per-call options.
+//
+//	out := ParallelMap(Parallel(e).WithWorkers(4).WithChunkSize(1024), f)
+func Parallel[V any](e IEnumerable[V]) *ParallelEnumerable[V] {
+	if p, ok := e.(*ParallelEnumerable[V]); ok {
+		return p
+	}
+	return &ParallelEnumerable[V]{source: e}
+}
+
+// WithOptions returns a copy of p which uses opts.
+func (p *ParallelEnumerable[V]) WithOptions(opts ParallelOptions) *ParallelEnumerable[V] {
+	return &ParallelEnumerable[V]{source: p.source, opts: opts}
+}
+
+// WithWorkers returns a copy of p which uses at most n goroutines.
+func (p *ParallelEnumerable[V]) WithWorkers(n int) *ParallelEnumerable[V] {
+	opts := p.opts
+	opts.Workers = n
+	return p.WithOptions(opts)
+}
+
+// WithChunkSize returns a copy of p which gives each goroutine at least k values.
+func (p *ParallelEnumerable[V]) WithChunkSize(k int) *ParallelEnumerable[V] {
+	opts := p.opts
+	opts.MinChunkSize = k
+	return p.WithOptions(opts)
+}
+
+// WithStrategy returns a copy of p which uses the scheduling strategy s.
+func (p *ParallelEnumerable[V]) WithStrategy(s Strategy) *ParallelEnumerable[V] {
+	opts := p.opts
+	opts.Strategy = s
+	return p.WithOptions(opts)
+}
+
+// Options returns the options of p.
+func (p *ParallelEnumerable[V]) Options() ParallelOptions {
+	return p.opts
+}
+
+// GetEnumerator returns the enumerator of the wrapped IEnumerable.
+func (p *ParallelEnumerable[V]) GetEnumerator() IEnumerator[V] {
+	return p.source.GetEnumerator()
+}
+
+// Values returns the values of the wrapped IEnumerable.
+func (p *ParallelEnumerable[V]) Values() []V {
+	return p.source.Values()
+}
+
+// workers returns the number of goroutines to use, falling back to the package default.
+func (o ParallelOptions) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	if c := int(cc.Load()); c > 0 {
+		return c
+	}
+	return 1
+}
+
+func (o ParallelOptions) minChunkSize() int {
+	if o.MinChunkSize > 0 {
+		return o.MinChunkSize
+	}
+	return 1
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"math/rand"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+// maxActive returns the highest number of concurrent calls to f made by ParallelEach over e.
+func maxActive(e enumerator.IEnumerable[int]) int64 {
+	active := atomic.Int64{}
+	peak := atomic.Int64{}
+	enumerator.ParallelEach(e, func(int) {
+		n := active.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Microsecond)
+		active.Add(-1)
+	})
+	return peak.Load()
+}
+
+func TestParallelOptions(t *testing.T) {
+	small := enumerator.Range(0, 63)
+	if n := maxActive(enumerator.Parallel(small).WithWorkers(1)); n != 1 {
+		t.Errorf("Expected WithWorkers(1) to run one goroutine, got %d", n)
+	}
+	if n := maxActive(enumerator.Parallel(small).WithChunkSize(64)); n != 1 {
+		t.Errorf("Expected WithChunkSize(64) to run one goroutine, got %d", n)
+	}
+	if n := maxActive(enumerator.Parallel(small).WithOptions(enumerator.ParallelOptions{Workers: 2})); n > 2 {
+		t.Errorf("Expected Workers: 2 to run at most two goroutines, got %d", n)
+	}
+
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	base := enumerator.Parallel(s).WithWorkers(3)
+	configured := base.WithChunkSize(100)
+	if base.Options().MinChunkSize != 0 || configured.Options().Workers != 3 {
+		t.Errorf("Expected With methods to copy, got %+v and %+v", base.Options(), configured.Options())
+	}
+	if enumerator.Parallel[int](configured) != configured {
+		t.Error("Expected Parallel to return an already wrapped enumerable")
+	}
+
+	out := enumerator.ParallelMap[int](configured, func(i int) int {
+		return i * 2
+	})
+	for i, v := range out.Values() {
+		if v != s.Values()[i]*2 {
+			t.Errorf("Expected ParallelMap to return %d at index %d, got %d", s.Values()[i]*2, i, v)
+		}
+	}
+	evens := enumerator.ParallelFilter[int](configured, func(i int) bool {
+		return i%2 == 0
+	})
+	if len(evens.Values()) != PERM_SIZE/2 {
+		t.Errorf("Expected ParallelFilter to return %d elements, got %d", PERM_SIZE/2, len(evens.Values()))
+	}
+}
+
+func TestSetConcurrencyRace(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	defer enumerator.SetConcurrency(runtime.NumCPU())
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 4; i++ {
+		wg.Add(2)
+		go func(c int) {
+			defer wg.Done()
+			enumerator.SetConcurrency(c)
+		}(i)
+		go func() {
+			defer wg.Done()
+			if n := enumerator.ParallelCount[int](s, func(int) bool { return true }); n != PERM_SIZE {
+				t.Errorf("Expected ParallelCount to return %d, got %d", PERM_SIZE, n)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestParallelDynamic(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	for _, chunk := range []int{0, 1, 7, PERM_SIZE} {
+		d := enumerator.Parallel(s).WithStrategy(enumerator.StrategyDynamic).WithWorkers(4).WithChunkSize(chunk)
+
+		out := enumerator.ParallelMap[int](d, func(i int) int {
+			return i * 2
+		})
+		for i, v := range out.Values() {
+			if v != s.Values()[i]*2 {
+				t.Fatalf("Expected ParallelMap with chunk size %d to return %d at index %d, got %d", chunk, s.Values()[i]*2, i, v)
+			}
+		}
+
+		evens := enumerator.ParallelFilter[int](d, func(i int) bool {
+			return i%2 == 0
+		}).Values()
+		expected := enumerator.Filter[int](s, func(i int) bool {
+			return i%2 == 0
+		}).Values()
+		if !reflect.DeepEqual(evens, expected) {
+			t.Errorf("Expected ParallelFilter with chunk size %d to preserve order", chunk)
+		}
+
+		sum := enumerator.ParallelReduce[int](d, func(acc, i int) int {
+			return acc + i
+		}, func(r []int) int {
+			total := 0
+			for _, v := range r {
+				total += v
+			}
+			return total
+		}, 0)
+		if sum != PERM_SIZE*(PERM_SIZE-1)/2 {
+			t.Errorf("Expected ParallelReduce with chunk size %d to return %d, got %d", chunk, PERM_SIZE*(PERM_SIZE-1)/2, sum)
+		}
+
+		if enumerator.ParallelAll[int](d, func(i int) bool { return i != 5 }) {
+			t.Errorf("Expected ParallelAll with chunk size %d to return false", chunk)
+		}
+	}
+}
+
+func TestParallelDynamicBalances(t *testing.T) {
+	// The first value doesn't finish until every other value has been
+	// processed, which a static split could never do since the rest of its
+	// partition would be stuck behind it
+	d := enumerator.Parallel(enumerator.Range(0, 64)).WithStrategy(enumerator.StrategyDynamic).WithWorkers(2).WithChunkSize(1)
+	done := atomic.Int64{}
+	balanced := atomic.Bool{}
+	enumerator.ParallelEach[int](d, func(i int) {
+		if i != 0 {
+			done.Add(1)
+			return
+		}
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if done.Load() == 63 {
+				balanced.Store(true)
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+	if !balanced.Load() {
+		t.Errorf("Expected the other worker to process the remaining values, it processed %d", done.Load())
+	}
+}
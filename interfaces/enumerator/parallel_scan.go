@@ -0,0 +1,71 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+// ParallelScan returns the same results as Scan, computed in parallel.
+//
+// f must be associative, since the elements are not combined strictly from
+// left to right. Each partition is scanned in parallel, the partition totals
+// are scanned, and then each partition is offset by the total of the
+// partitions before it in parallel.
+func ParallelScan[V any](e IEnumerable[V], f func(V, V) V) IEnumerable[V] {
+	pe := asParallel(e)
+	result := make([]V, pe.length)
+	parallelScan(pe, f, result)
+	return GetSliceEnumerable(result)
+}
+
+// ParallelExclusiveScan returns the same results as ExclusiveScan, computed in parallel.
+//
+// f must be associative, see ParallelScan.
+func ParallelExclusiveScan[V any](e IEnumerable[V], f func(V, V) V, identity V) IEnumerable[V] {
+	pe := asParallel(e)
+	// The inclusive scan shifted one to the right is the exclusive scan
+	result := make([]V, pe.length+1)
+	result[0] = identity
+	parallelScan(pe, f, result[1:])
+	return GetSliceEnumerable(result[:pe.length])
+}
+
+// parallelScan writes the inclusive scan of pe into result using the two-pass block algorithm.
+func parallelScan[V any](pe *parallelEnumerator[V], f func(V, V) V, result []V) {
+	// Pass one: scan each partition on its own
+	pe.run(func(idx int, p []V) {
+		r := result[pe.offsets[idx]:]
+		r[0] = p[0]
+		for i := 1; i < len(p); i++ {
+			r[i] = f(r[i-1], p[i])
+		}
+	})
+	if len(pe.partitions) < 2 {
+		return
+	}
+
+	// Scan the partition totals, prefixes[i] is the total of partitions [0, i]
+	prefixes := make([]V, len(pe.partitions))
+	for idx, p := range pe.partitions {
+		total := result[pe.offsets[idx]+len(p)-1]
+		if idx == 0 {
+			prefixes[idx] = total
+		} else {
+			prefixes[idx] = f(prefixes[idx-1], total)
+		}
+	}
+
+	// Pass two: offset every partition after the first by the partitions before it
+	pe.run(func(idx int, p []V) {
+		if idx == 0 {
+			return
+		}
+		r := result[pe.offsets[idx] : pe.offsets[idx]+len(p)]
+		for i := range r {
+			r[i] = f(prefixes[idx-1], r[i])
+		}
+	})
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+func TestParallelScan(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	add := func(a, b int) int { return a + b }
+	inclusive := enumerator.Scan(s, add).Values()
+	exclusive := enumerator.ExclusiveScan(s, add, 0).Values()
+
+	for _, opts := range []enumerator.ParallelOptions{
+		{Workers: 1},
+		{Workers: 3},
+		{Workers: 8},
+		{Workers: 4, Strategy: enumerator.StrategyDynamic, MinChunkSize: 5},
+	} {
+		p := enumerator.Parallel(s).WithOptions(opts)
+		if out := enumerator.ParallelScan[int](p, add).Values(); !reflect.DeepEqual(out, inclusive) {
+			t.Errorf("Expected ParallelScan with %+v to match Scan", opts)
+		}
+		if out := enumerator.ParallelExclusiveScan[int](p, add, 0).Values(); !reflect.DeepEqual(out, exclusive) {
+			t.Errorf("Expected ParallelExclusiveScan with %+v to match ExclusiveScan", opts)
+		}
+	}
+
+	empty := enumerator.GetSliceEnumerable([]int{})
+	if out := enumerator.ParallelScan(empty, add).Values(); len(out) != 0 {
+		t.Errorf("Expected ParallelScan of an empty enumerable to be empty, got %v", out)
+	}
+	if out := enumerator.ParallelExclusiveScan(empty, add, 0).Values(); len(out) != 0 {
+		t.Errorf("Expected ParallelExclusiveScan of an empty enumerable to be empty, got %v", out)
+	}
+}
+
+func TestParallelScanNonCommutative(t *testing.T) {
+	// Concatenation is associative but not commutative, so this checks that
+	// partition prefixes are applied on the left
+	s := enumerator.Map(enumerator.Range(0, 64), strconv.Itoa)
+	concat := func(a, b string) string { return a + b }
+
+	p := enumerator.Parallel(s).WithWorkers(5)
+	if out := enumerator.ParallelScan[string](p, concat).Values(); !reflect.DeepEqual(out, enumerator.Scan(s, concat).Values()) {
+		t.Errorf("Expected ParallelScan to match Scan, got %v", out)
+	}
+	if out := enumerator.ParallelExclusiveScan[string](p, concat, "").Values(); !reflect.DeepEqual(out, enumerator.ExclusiveScan(s, concat, "").Values()) {
+		t.Errorf("Expected ParallelExclusiveScan to match ExclusiveScan, got %v", out)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ParallelSort returns a new IEnumerable with the elements of e in ascending order.
+//
+// Each partition is sorted in parallel and the partitions are then merged.
+// e is not modified.
+func ParallelSort[V constraints.Ordered](e IEnumerable[V]) IEnumerable[V] {
+	return ParallelSortFunc(e, cmp.Compare[V])
+}
+
+// ParallelSortFunc returns a new IEnumerable with the elements of e sorted by cmp.
+//
+// cmp must return a negative number, zero, or a positive number when a is
+// less than, equal to, or greater than b. The sort is not guaranteed to be
+// stable, use ParallelSortStableFunc to keep equal elements in their original order.
+func ParallelSortFunc[V any](e IEnumerable[V], cmp func(a, b V) int) IEnumerable[V] {
+	return GetSliceEnumerable(parallelSort(e, cmp, slices.SortFunc[[]V]))
+}
+
+// ParallelSortStableFunc returns a new IEnumerable with the elements of e sorted by cmp,
+// keeping equal elements in their original order.
+func ParallelSortStableFunc[V any](e IEnumerable[V], cmp func(a, b V) int) IEnumerable[V] {
+	return GetSliceEnumerable(parallelSort(e, cmp, slices.SortStableFunc[[]V]))
+}
+
+// parallelSort copies each partition of e into its place in the result and
+// sorts it there with sortFunc, then merges the sorted partitions.
+//
+// The merge takes from the earlier partition on ties, so the result is
+// stable if sortFunc is.
+func parallelSort[V any](e IEnumerable[V], cmp func(a, b V) int, sortFunc func([]V, func(a, b V) int)) []V {
+	pe := asParallel(e)
+	bounds := make([]int, len(pe.partitions)+1)
+	for i, p := range pe.partitions {
+		bounds[i+1] = bounds[i] + len(p)
+	}
+	sorted := make([]V, pe.length)
+	pe.run(func(idx int, p []V) {
+		s := sorted[bounds[idx]:bounds[idx+1]]
+		copy(s, p)
+		sortFunc(s, cmp)
+	})
+	return mergeRuns(sorted, bounds, cmp)
+}
+
+// mergeRuns merges the sorted runs of values, which start at each of bounds
+// but the last, and returns the merged slice.
+//
+// Each round merges neighbouring pairs of runs in parallel, halving the
+// number of runs, so k runs are merged in log2(k) rounds rather than one
+// sequential pass.
+func mergeRuns[V any](values []V, bounds []int, cmp func(a, b V) int) []V {
+	if len(bounds) <= 2 {
+		return values
+	}
+	buf := make([]V, len(values))
+	for len(bounds) > 2 {
+		next := make([]int, 0, len(bounds)/2+1)
+		var wg sync.WaitGroup
+		for i := 0; i < len(bounds)-1; i += 2 {
+			lo := bounds[i]
+			next = append(next, lo)
+			if i+2 == len(bounds) {
+				// The odd run out waits for the next round
+				copy(buf[lo:], values[lo:])
+				break
+			}
+			mid, hi := bounds[i+1], bounds[i+2]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				mergeInto(buf[lo:hi], values[lo:mid], values[mid:hi], cmp)
+			}()
+		}
+		wg.Wait()
+		values, buf = buf, values
+		bounds = append(next, len(values))
+	}
+	return values
+}
+
+// mergeInto merges the sorted slices a and b into dst, which must have room for both.
+//
+// Ties take from a, so the merge is stable.
+func mergeInto[V any](dst, a, b []V, cmp func(a, b V) int) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+func TestParallelSort(t *testing.T) {
+	values := rand.Perm(PERM_SIZE)
+	s := enumerator.GetSliceEnumerable(values)
+	original := slices.Clone(values)
+
+	out := enumerator.ParallelSort(s)
+	if !reflect.DeepEqual(out.Values(), enumerator.Range(0, PERM_SIZE).Values()) {
+		t.Errorf("Expected ParallelSort to return [0, %d), got %v", PERM_SIZE, out.Values())
+	}
+	if !reflect.DeepEqual(values, original) {
+		t.Error("Expected ParallelSort not to modify its input")
+	}
+
+	for _, opts := range []enumerator.ParallelOptions{
+		{Workers: 1},
+		{Workers: 3},
+		{Workers: 4, Strategy: enumerator.StrategyDynamic, MinChunkSize: 10},
+	} {
+		out := enumerator.ParallelSort[int](enumerator.Parallel(s).WithOptions(opts))
+		if !slices.IsSorted(out.Values()) || len(out.Values()) != PERM_SIZE {
+			t.Errorf("Expected ParallelSort with %+v to sort, got %v", opts, out.Values())
+		}
+	}
+
+	if len(enumerator.ParallelSort(enumerator.GetSliceEnumerable([]int{})).Values()) != 0 {
+		t.Error("Expected ParallelSort of an empty enumerable to be empty")
+	}
+}
+
+func TestParallelSortFunc(t *testing.T) {
+	words := enumerator.GetSliceEnumerable([]string{"pear", "Apple", "fig", "banana", "Cherry"})
+	out := enumerator.ParallelSortFunc(enumerator.Parallel(words).WithWorkers(2), func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	expected := []string{"Apple", "banana", "Cherry", "fig", "pear"}
+	if !reflect.DeepEqual(out.Values(), expected) {
+		t.Errorf("Expected ParallelSortFunc to return %v, got %v", expected, out.Values())
+	}
+}
+
+func TestParallelSortStableFunc(t *testing.T) {
+	type pair struct{ key, order int }
+	pairs := make([]pair, PERM_SIZE)
+	for i := range pairs {
+		pairs[i] = pair{rand.Intn(16), i}
+	}
+	byKey := func(a, b pair) int { return a.key - b.key }
+
+	expected := slices.Clone(pairs)
+	slices.SortStableFunc(expected, byKey)
+	for _, workers := range []int{1, 2, 5, 8} {
+		s := enumerator.Parallel(enumerator.GetSliceEnumerable(pairs)).WithWorkers(workers)
+		out := enumerator.ParallelSortStableFunc[pair](s, byKey)
+		if !reflect.DeepEqual(out.Values(), expected) {
+			t.Errorf("Expected ParallelSortStableFunc with %d workers to keep equal keys in order", workers)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+import "context"
+
+// ParallelMapChan applies f to each value received from in using workers goroutines,
+// and sends the results on the returned channel in the order the values were received.
+//
+// At most buffer values are held between being received from in and their
+// result being sent, so memory use is constant however many values in
+// produces. buffer should be at least workers to keep every worker busy.
+// Values <= 0 for workers use the package default set by SetConcurrency, and
+// values <= 0 for buffer use twice the number of workers.
+//
+// The returned channel is closed once in is closed and every result has been
+// sent, or as soon as ctx is cancelled. A caller which stops receiving early
+// must cancel ctx so the goroutines can exit. Calls to f which are running
+// when ctx is cancelled finish in the background after the channel is closed.
+func ParallelMapChan[V any, R any](ctx context.Context, in <-chan V, f func(V) R, workers, buffer int) <-chan R {
+	next := func() (V, bool) {
+		select {
+		case v, ok := <-in:
+			return v, ok
+		case <-ctx.Done():
+			return *new(V), false
+		}
+	}
+	return streamMap(ctx, next, f, workers, buffer)
+}
+
+// ParallelMapEnumerator applies f to each value of e using workers goroutines,
+// and sends the results on the returned channel in the order of e.
+//
+// e is only advanced by a single goroutine and may be unbounded. See
+// ParallelMapChan for workers, buffer and when the channel is closed.
+//
+// e.Next can't observe ctx, so the channel is closed on cancellation even
+// while a call to e.Next is blocked, and that goroutine exits once the call
+// returns. e must not be used again until then.
+func ParallelMapEnumerator[V any, R any](ctx context.Context, e IEnumerator[V], f func(V) R, workers, buffer int) <-chan R {
+	next := func() (V, bool) {
+		if !e.Next() {
+			return *new(V), false
+		}
+		return e.Current(), true
+	}
+	return streamMap(ctx, next, f, workers, buffer)
+}
+
+// streamJob is a value waiting for a worker, and where to send its result.
+type streamJob[V any, R any] struct {
+	value  V
+	result chan R
+}
+
+// streamMap reads values from next until it reports false, mapping them with f.
+//
+// Each value gets a result channel which is queued in pending in input order
+// before the value is handed to the workers. The emitter waits on the result
+// channels in queue order, so results leave in input order, and the capacity
+// of pending bounds the number of values in flight.
+func streamMap[V any, R any](ctx context.Context, next func() (V, bool), f func(V) R, workers, buffer int) <-chan R {
+	if workers <= 0 {
+		workers = ParallelOptions{}.workers()
+	}
+	if buffer <= 0 {
+		buffer = 2 * workers
+	}
+
+	jobs := make(chan streamJob[V, R])
+	pending := make(chan chan R, buffer)
+	out := make(chan R)
+
+	// Dispatcher
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for {
+			if isDone(ctx) {
+				return
+			}
+			v, ok := next()
+			if !ok {
+				return
+			}
+			result := make(chan R, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- streamJob[V, R]{v, result}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Workers never block on sending a result, since each result channel has
+	// room for one, so they exit once the dispatcher closes jobs.
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				job.result <- f(job.value)
+			}
+		}()
+	}
+
+	// Emitter, which doesn't wait on the dispatcher or workers so out is
+	// closed promptly when ctx is cancelled, even if next or f is blocked.
+	go func() {
+		defer close(out)
+		for {
+			var result chan R
+			select {
+			case r, ok := <-pending:
+				if !ok {
+					return
+				}
+				result = r
+			case <-ctx.Done():
+				return
+			}
+			var r R
+			select {
+			case r = <-result:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+func TestParallelMapChan(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 0; i < PERM_SIZE; i++ {
+			in <- i
+		}
+	}()
+
+	out := enumerator.ParallelMapChan(context.Background(), in, func(i int) int {
+		// Later values often finish first
+		time.Sleep(time.Duration(rand.Intn(50)) * time.Microsecond)
+		return i * 2
+	}, 4, 8)
+
+	expected := 0
+	for v := range out {
+		if v != expected*2 {
+			t.Fatalf("Expected ParallelMapChan to return %d at index %d, got %d", expected*2, expected, v)
+		}
+		expected++
+	}
+	if expected != PERM_SIZE {
+		t.Errorf("Expected ParallelMapChan to return %d values, got %d", PERM_SIZE, expected)
+	}
+}
+
+func TestParallelMapEnumeratorBounded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	read := atomic.Int64{}
+	source := enumerator.LazyMap(enumerator.Iterate(0, func(i int) int { return i + 1 }), func(i int) int {
+		read.Add(1)
+		return i
+	})
+	const buffer = 4
+	out := enumerator.ParallelMapEnumerator(ctx, source.GetEnumerator(), func(i int) int {
+		return i + 1
+	}, 2, buffer)
+
+	for i := 0; i < 100; i++ {
+		if v := <-out; v != i+1 {
+			t.Fatalf("Expected ParallelMapEnumerator to return %d at index %d, got %d", i+1, i, v)
+		}
+		// Give the dispatcher time to read ahead as far as it can
+		time.Sleep(100 * time.Microsecond)
+		// The buffered values, one waiting to be queued, and one waiting to be sent
+		if ahead := read.Load() - int64(i+1); ahead > buffer+2 {
+			t.Fatalf("Expected at most %d values to be read ahead, got %d", buffer+2, ahead)
+		}
+	}
+}
+
+func TestParallelMapStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	source := enumerator.Repeat(1).GetEnumerator()
+	out := enumerator.ParallelMapEnumerator(ctx, source, func(i int) int {
+		return i
+	}, 0, 0)
+
+	<-out
+	cancel()
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("Expected the output channel to close after cancellation")
+		}
+	}
+}
+
+func TestParallelMapStreamCancelBlockedSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	blocked, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	// The source blocks in Next after its first value until released
+	source := enumerator.FromSeq(func(yield func(int) bool) {
+		if yield(1) {
+			close(blocked)
+			<-release
+		}
+	}).GetEnumerator()
+	out := enumerator.ParallelMapEnumerator(ctx, source, func(i int) int {
+		return i
+	}, 0, 0)
+
+	<-out
+	<-blocked
+	cancel()
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Error("Expected no more results after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the output channel to close while the source is blocked")
+	}
+}
@@ -9,7 +9,12 @@
 package enumerator_test
 
 import (
+	"context"
+	"errors"
 	"math/rand"
+	"reflect"
+	"runtime"
+	"sync/atomic"
 	"testing"
 
 	"github.com/glasket/datastructures/interfaces/enumerator"
@@ -127,3 +132,144 @@ func TestParallelReduce(t *testing.T) {
 		t.Errorf("Expected ParallelReduce to return %d, got %d", expected, out)
 	}
 }
+
+func TestParallelCtxCancelled(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := atomic.Int64{}
+	if err := enumerator.ParallelEachCtx(ctx, s, func(int) { calls.Add(1) }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected ParallelEachCtx to return context.Canceled, got %v", err)
+	}
+	if all, err := enumerator.ParallelAllCtx(ctx, s, func(int) bool { calls.Add(1); return true }); all || err == nil {
+		t.Errorf("Expected ParallelAllCtx to return false and an error, got %t and %v", all, err)
+	}
+	if found, err := enumerator.ParallelAnyCtx(ctx, s, func(int) bool { calls.Add(1); return false }); found || err == nil {
+		t.Errorf("Expected ParallelAnyCtx to return false and an error, got %t and %v", found, err)
+	}
+	if _, err := enumerator.ParallelCountCtx(ctx, s, func(int) bool { calls.Add(1); return true }); err == nil {
+		t.Error("Expected ParallelCountCtx to return an error")
+	}
+	if out, err := enumerator.ParallelMapCtx(ctx, s, func(i int) int { calls.Add(1); return i }); out != nil || err == nil {
+		t.Errorf("Expected ParallelMapCtx to return nil and an error, got %v and %v", out, err)
+	}
+	if out, err := enumerator.ParallelFilterCtx(ctx, s, func(int) bool { calls.Add(1); return true }); out != nil || err == nil {
+		t.Errorf("Expected ParallelFilterCtx to return nil and an error, got %v and %v", out, err)
+	}
+	sum := func(r []int) int { return 0 }
+	if _, err := enumerator.ParallelReduceCtx(ctx, s, func(acc, i int) int { calls.Add(1); return acc + i }, sum, func() int { return 0 }); err == nil {
+		t.Error("Expected ParallelReduceCtx to return an error")
+	}
+	if calls.Load() != 0 {
+		t.Errorf("Expected no calls with a cancelled context, got %d", calls.Load())
+	}
+}
+
+func TestParallelCtxStopsPromptly(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := atomic.Int64{}
+	err := enumerator.ParallelEachCtx(ctx, s, func(int) {
+		if calls.Add(1) == 10 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected ParallelEachCtx to return context.Canceled, got %v", err)
+	}
+	// Each partition may finish the element it was on when cancel was called
+	if n := calls.Load(); n > int64(10+runtime.NumCPU()) {
+		t.Errorf("Expected partitions to stop after cancellation, got %d calls", n)
+	}
+}
+
+func TestParallelCtxShortCircuit(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	if all, err := enumerator.ParallelAllCtx(context.Background(), s, func(i int) bool { return i != 5 }); all || err != nil {
+		t.Errorf("Expected ParallelAllCtx to return false and no error, got %t and %v", all, err)
+	}
+	if found, err := enumerator.ParallelAnyCtx(context.Background(), s, func(i int) bool { return i == 5 }); !found || err != nil {
+		t.Errorf("Expected ParallelAnyCtx to return true and no error, got %t and %v", found, err)
+	}
+}
+
+func TestParallelReduceSeedsPartitions(t *testing.T) {
+	s := enumerator.GetSliceEnumerable([]int{3, 2, 5, 1, 4})
+	product := func(acc, i int) int { return acc * i }
+	combine := func(r []int) int {
+		total := 1
+		for _, v := range r {
+			total *= v
+		}
+		return total
+	}
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		p := enumerator.Parallel(s).WithWorkers(workers)
+		if out := enumerator.ParallelReduce[int](p, product, combine, 1); out != 120 {
+			t.Errorf("Expected ParallelReduce with %d workers to return 120, got %d", workers, out)
+		}
+		out, err := enumerator.ParallelReduceErr[int](context.Background(), p, func(acc, i int) (int, error) {
+			return acc * i, nil
+		}, combine, func() int { return 1 }, enumerator.FailFast)
+		if err != nil || out != 120 {
+			t.Errorf("Expected ParallelReduceErr with %d workers to return 120, got %d and %v", workers, out, err)
+		}
+	}
+
+	calls := 0
+	out := enumerator.ParallelReduce(enumerator.GetSliceEnumerable([]int{}), product, func(r []int) int {
+		calls++
+		return 0
+	}, 1)
+	if out != 1 || calls != 0 {
+		t.Errorf("Expected ParallelReduce of an empty enumerable to return initial without combining, got %d", out)
+	}
+}
+
+func TestParallelReduceFuncSeedsEachPartition(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	count := func(acc map[int]int, i int) map[int]int {
+		acc[i%10]++
+		return acc
+	}
+	merge := func(r []map[int]int) map[int]int {
+		total := make(map[int]int)
+		for _, m := range r {
+			for k, v := range m {
+				total[k] += v
+			}
+		}
+		return total
+	}
+
+	expected := count(make(map[int]int), 0)
+	for i := 1; i < PERM_SIZE; i++ {
+		count(expected, i)
+	}
+
+	seeds := atomic.Int64{}
+	for _, workers := range []int{1, 4, 8} {
+		p := enumerator.Parallel(s).WithWorkers(workers)
+		// Each partition counts into its own map, so this is race free
+		out := enumerator.ParallelReduceFunc(p, count, merge, func() map[int]int {
+			seeds.Add(1)
+			return make(map[int]int)
+		})
+		if !reflect.DeepEqual(out, expected) {
+			t.Errorf("Expected ParallelReduceFunc with %d workers to return %v, got %v", workers, expected, out)
+		}
+		out, err := enumerator.ParallelReduceErr(context.Background(), p, func(acc map[int]int, i int) (map[int]int, error) {
+			return count(acc, i), nil
+		}, merge, func() map[int]int { return make(map[int]int) }, enumerator.FailFast)
+		if err != nil || !reflect.DeepEqual(out, expected) {
+			t.Errorf("Expected ParallelReduceErr to count into a map per partition, got %v and %v", out, err)
+		}
+	}
+	if seeds.Load() < 3 {
+		t.Errorf("Expected seed to be called for every partition, got %d calls", seeds.Load())
+	}
+}
@@ -0,0 +1,367 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Take returns a new enumerable with at most the first n elements of the enumerable.
+//
+// The source is not enumerated past the nth element.
+func Take[V any](e IEnumerable[V], n int) IEnumerable[V] {
+	enum := e.GetEnumerator()
+	results := make([]V, 0)
+	for len(results) < n && enum.Next() {
+		results = append(results, enum.Current())
+	}
+	return GetSliceEnumerable(results)
+}
+
+// Skip returns a new enumerable without the first n elements of the enumerable.
+func Skip[V any](e IEnumerable[V], n int) IEnumerable[V] {
+	enum := e.GetEnumerator()
+	results := make([]V, 0)
+	for i := 0; enum.Next(); i++ {
+		if i >= n {
+			results = append(results, enum.Current())
+		}
+	}
+	return GetSliceEnumerable(results)
+}
+
+// TakeWhile returns a new enumerable with the elements of the enumerable up to
+// the first element for which the given function returns false.
+func TakeWhile[V any](e IEnumerable[V], f func(V) bool) IEnumerable[V] {
+	enum := e.GetEnumerator()
+	results := make([]V, 0)
+	for enum.Next() && f(enum.Current()) {
+		results = append(results, enum.Current())
+	}
+	return GetSliceEnumerable(results)
+}
+
+// SkipWhile returns a new enumerable with the elements of the enumerable starting
+// at the first element for which the given function returns false.
+func SkipWhile[V any](e IEnumerable[V], f func(V) bool) IEnumerable[V] {
+	enum := e.GetEnumerator()
+	results := make([]V, 0)
+	skipping := true
+	for enum.Next() {
+		if skipping && f(enum.Current()) {
+			continue
+		}
+		skipping = false
+		results = append(results, enum.Current())
+	}
+	return GetSliceEnumerable(results)
+}
+
+// First returns the first element of the enumerable, or an error if it is empty.
+func First[V any](e IEnumerable[V]) (V, error) {
+	enum := e.GetEnumerator()
+	if !enum.Next() {
+		return *new(V), fmt.Errorf("enumerable is empty")
+	}
+	return enum.Current(), nil
+}
+
+// Last returns the last element of the enumerable, or an error if it is empty.
+func Last[V any](e IEnumerable[V]) (V, error) {
+	enum := e.GetEnumerator()
+	if !enum.Next() {
+		return *new(V), fmt.Errorf("enumerable is empty")
+	}
+	last := enum.Current()
+	for enum.Next() {
+		last = enum.Current()
+	}
+	return last, nil
+}
+
+// ElementAt returns the element at index i of the enumerable, or an error if
+// the enumerable has no such element.
+func ElementAt[V any](e IEnumerable[V], i int) (V, error) {
+	if i >= 0 {
+		enum := e.GetEnumerator()
+		for j := 0; enum.Next(); j++ {
+			if j == i {
+				return enum.Current(), nil
+			}
+		}
+	}
+	return *new(V), fmt.Errorf("index %d out of bounds", i)
+}
+
+// Concat returns a new enumerable with the elements of each enumerable in turn.
+func Concat[V any](es ...IEnumerable[V]) IEnumerable[V] {
+	results := make([]V, 0)
+	for _, e := range es {
+		enum := e.GetEnumerator()
+		for enum.Next() {
+			results = append(results, enum.Current())
+		}
+	}
+	return GetSliceEnumerable(results)
+}
+
+// Zip returns a new enumerable with the given function applied to the elements
+// of both enumerables pairwise.
+//
+// The result is as long as the shorter of the enumerables.
+func Zip[V any, U any, R any](e IEnumerable[V], other IEnumerable[U], f func(V, U) R) IEnumerable[R] {
+	enum := e.GetEnumerator()
+	otherEnum := other.GetEnumerator()
+	results := make([]R, 0)
+	for enum.Next() && otherEnum.Next() {
+		results = append(results, f(enum.Current(), otherEnum.Current()))
+	}
+	return GetSliceEnumerable(results)
+}
+
+// FlatMap returns a new enumerable with the elements of the enumerables the
+// given function returns for each element, flattened in order.
+func FlatMap[V any, R any](e IEnumerable[V], f func(V) IEnumerable[R]) IEnumerable[R] {
+	enum := e.GetEnumerator()
+	results := make([]R, 0)
+	for enum.Next() {
+		inner := f(enum.Current()).GetEnumerator()
+		for inner.Next() {
+			results = append(results, inner.Current())
+		}
+	}
+	return GetSliceEnumerable(results)
+}
+
+// Distinct returns a new enumerable with the first occurrence of each element.
+func Distinct[V comparable](e IEnumerable[V]) IEnumerable[V] {
+	enum := e.GetEnumerator()
+	seen := make(map[V]struct{})
+	results := make([]V, 0)
+	for enum.Next() {
+		if _, ok := seen[enum.Current()]; !ok {
+			seen[enum.Current()] = struct{}{}
+			results = append(results, enum.Current())
+		}
+	}
+	return GetSliceEnumerable(results)
+}
+
+// Grouping is a key and the elements that share it.
+type Grouping[K comparable, V any] struct {
+	Key    K
+	Values []V
+}
+
+// GroupBy returns a new enumerable of the elements grouped by the key the given
+// function returns for them.
+//
+// Groups are ordered by the first occurrence of their key, and the elements of
+// each group keep their order.
+func GroupBy[V any, K comparable](e IEnumerable[V], key func(V) K) IEnumerable[Grouping[K, V]] {
+	enum := e.GetEnumerator()
+	index := make(map[K]int)
+	results := make([]Grouping[K, V], 0)
+	for enum.Next() {
+		k := key(enum.Current())
+		i, ok := index[k]
+		if !ok {
+			i = len(results)
+			index[k] = i
+			results = append(results, Grouping[K, V]{Key: k, Values: make([]V, 0)})
+		}
+		results[i].Values = append(results[i].Values, enum.Current())
+	}
+	return GetSliceEnumerable(results)
+}
+
+// OrderedEnumerable is an enumerable sorted by one or more keys.
+//
+// The sort is stable and is performed each time the elements are requested,
+// so it reflects the current contents of the source.
+type OrderedEnumerable[V any] struct {
+	source IEnumerable[V]
+	cmps   []func(a, b V) int
+}
+
+func (o *OrderedEnumerable[V]) GetEnumerator() IEnumerator[V] {
+	return GetSliceEnumerable(o.Values()).GetEnumerator()
+}
+
+func (o *OrderedEnumerable[V]) Values() []V {
+	src := o.source.Values()
+	values := make([]V, len(src))
+	copy(values, src)
+	sort.SliceStable(values, func(i, j int) bool {
+		for _, cmp := range o.cmps {
+			if c := cmp(values[i], values[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return values
+}
+
+func (o *OrderedEnumerable[V]) then(cmp func(a, b V) int) *OrderedEnumerable[V] {
+	cmps := make([]func(a, b V) int, len(o.cmps), len(o.cmps)+1)
+	copy(cmps, o.cmps)
+	return &OrderedEnumerable[V]{
+		source: o.source,
+		cmps:   append(cmps, cmp),
+	}
+}
+
+func ascending[V any, K constraints.Ordered](key func(V) K) func(a, b V) int {
+	return func(a, b V) int {
+		ka, kb := key(a), key(b)
+		if ka < kb {
+			return -1
+		}
+		if ka > kb {
+			return 1
+		}
+		return 0
+	}
+}
+
+func descending[V any, K constraints.Ordered](key func(V) K) func(a, b V) int {
+	asc := ascending(key)
+	return func(a, b V) int {
+		return asc(b, a)
+	}
+}
+
+// OrderBy returns the elements of the enumerable sorted ascending by the key
+// the given function returns for them.
+func OrderBy[V any, K constraints.Ordered](e IEnumerable[V], key func(V) K) *OrderedEnumerable[V] {
+	return &OrderedEnumerable[V]{
+		source: e,
+		cmps:   []func(a, b V) int{ascending(key)},
+	}
+}
+
+// OrderByDescending returns the elements of the enumerable sorted descending by
+// the key the given function returns for them.
+func OrderByDescending[V any, K constraints.Ordered](e IEnumerable[V], key func(V) K) *OrderedEnumerable[V] {
+	return &OrderedEnumerable[V]{
+		source: e,
+		cmps:   []func(a, b V) int{descending(key)},
+	}
+}
+
+// ThenBy sorts elements that are equal under the previous keys ascending by
+// the key the given function returns for them.
+func ThenBy[V any, K constraints.Ordered](o *OrderedEnumerable[V], key func(V) K) *OrderedEnumerable[V] {
+	return o.then(ascending(key))
+}
+
+// ThenByDescending sorts elements that are equal under the previous keys
+// descending by the key the given function returns for them.
+func ThenByDescending[V any, K constraints.Ordered](o *OrderedEnumerable[V], key func(V) K) *OrderedEnumerable[V] {
+	return o.then(descending(key))
+}
+
+// Join returns a new enumerable with the given function applied to each pair of
+// outer and inner elements with matching keys.
+//
+// Results are ordered by the outer elements, then by the inner elements.
+func Join[O any, I any, K comparable, R any](outer IEnumerable[O], inner IEnumerable[I], outerKey func(O) K, innerKey func(I) K, f func(O, I) R) IEnumerable[R] {
+	lookup := toLookup(inner, innerKey)
+	enum := outer.GetEnumerator()
+	results := make([]R, 0)
+	for enum.Next() {
+		for _, i := range lookup[outerKey(enum.Current())] {
+			results = append(results, f(enum.Current(), i))
+		}
+	}
+	return GetSliceEnumerable(results)
+}
+
+// GroupJoin returns a new enumerable with the given function applied to each
+// outer element and the inner elements with a matching key.
+//
+// Every outer element produces a result, even if no inner elements match.
+func GroupJoin[O any, I any, K comparable, R any](outer IEnumerable[O], inner IEnumerable[I], outerKey func(O) K, innerKey func(I) K, f func(O, []I) R) IEnumerable[R] {
+	lookup := toLookup(inner, innerKey)
+	enum := outer.GetEnumerator()
+	results := make([]R, 0)
+	for enum.Next() {
+		matches := lookup[outerKey(enum.Current())]
+		if matches == nil {
+			matches = make([]I, 0)
+		}
+		results = append(results, f(enum.Current(), matches))
+	}
+	return GetSliceEnumerable(results)
+}
+
+func toLookup[V any, K comparable](e IEnumerable[V], key func(V) K) map[K][]V {
+	enum := e.GetEnumerator()
+	lookup := make(map[K][]V)
+	for enum.Next() {
+		k := key(enum.Current())
+		lookup[k] = append(lookup[k], enum.Current())
+	}
+	return lookup
+}
+
+// Min returns the smallest element of the enumerable, or an error if it is empty.
+func Min[V constraints.Ordered](e IEnumerable[V]) (V, error) {
+	return Aggregate(e, func(acc, v V) V {
+		if v < acc {
+			return v
+		}
+		return acc
+	})
+}
+
+// Max returns the largest element of the enumerable, or an error if it is empty.
+func Max[V constraints.Ordered](e IEnumerable[V]) (V, error) {
+	return Aggregate(e, func(acc, v V) V {
+		if v > acc {
+			return v
+		}
+		return acc
+	})
+}
+
+// Average returns the mean of the elements of the enumerable, or an error if it is empty.
+func Average[V constraints.Integer | constraints.Float](e IEnumerable[V]) (float64, error) {
+	enum := e.GetEnumerator()
+	var sum float64
+	count := 0
+	for enum.Next() {
+		sum += float64(enum.Current())
+		count += 1
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("enumerable is empty")
+	}
+	return sum / float64(count), nil
+}
+
+// Aggregate returns a single value by applying the given function to each element
+// in the enumerable, using the first element as the initial value.
+//
+// Returns an error if the enumerable is empty. Use Reduce to supply an initial value.
+func Aggregate[V any](e IEnumerable[V], f func(V, V) V) (V, error) {
+	enum := e.GetEnumerator()
+	if !enum.Next() {
+		return *new(V), fmt.Errorf("enumerable is empty")
+	}
+	result := enum.Current()
+	for enum.Next() {
+		result = f(result, enum.Current())
+	}
+	return result, nil
+}
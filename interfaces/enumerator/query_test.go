@@ -0,0 +1,210 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+func TestTakeSkip(t *testing.T) {
+	s := enumerator.Range(0, 10)
+	if got := enumerator.Take(s, 3).Values(); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("Take returned %v", got)
+	}
+	if got := enumerator.Take(s, 20).Values(); len(got) != 10 {
+		t.Errorf("Take past the end returned %v", got)
+	}
+	if got := enumerator.Skip(s, 7).Values(); !reflect.DeepEqual(got, []int{7, 8, 9}) {
+		t.Errorf("Skip returned %v", got)
+	}
+	if got := enumerator.Skip(s, 20).Values(); len(got) != 0 {
+		t.Errorf("Skip past the end returned %v", got)
+	}
+	lessThan3 := func(i int) bool { return i < 3 }
+	if got := enumerator.TakeWhile(s, lessThan3).Values(); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("TakeWhile returned %v", got)
+	}
+	s = enumerator.GetSliceEnumerable([]int{1, 2, 5, 1, 2})
+	if got := enumerator.SkipWhile(s, lessThan3).Values(); !reflect.DeepEqual(got, []int{5, 1, 2}) {
+		t.Errorf("SkipWhile returned %v", got)
+	}
+	// Take only enumerates what it needs, so it can bound infinite sources
+	if got := enumerator.Take(enumerator.Repeat(1), 3).Values(); !reflect.DeepEqual(got, []int{1, 1, 1}) {
+		t.Errorf("Take of an infinite enumerable returned %v", got)
+	}
+}
+
+func TestElementAccess(t *testing.T) {
+	s := enumerator.Range(5, 10)
+	if v, err := enumerator.First(s); v != 5 || err != nil {
+		t.Errorf("First returned %d (%v)", v, err)
+	}
+	if v, err := enumerator.Last(s); v != 9 || err != nil {
+		t.Errorf("Last returned %d (%v)", v, err)
+	}
+	if v, err := enumerator.ElementAt(s, 2); v != 7 || err != nil {
+		t.Errorf("ElementAt returned %d (%v)", v, err)
+	}
+	if _, err := enumerator.ElementAt(s, 5); err == nil {
+		t.Error("ElementAt did not error out of bounds")
+	}
+	if _, err := enumerator.ElementAt(s, -1); err == nil {
+		t.Error("ElementAt did not error on a negative index")
+	}
+	empty := enumerator.GetSliceEnumerable([]int{})
+	if _, err := enumerator.First(empty); err == nil {
+		t.Error("First did not error on an empty enumerable")
+	}
+	if _, err := enumerator.Last(empty); err == nil {
+		t.Error("Last did not error on an empty enumerable")
+	}
+}
+
+func TestCombining(t *testing.T) {
+	a := enumerator.Range(0, 3)
+	b := enumerator.Range(10, 12)
+	if got := enumerator.Concat(a, b, a).Values(); !reflect.DeepEqual(got, []int{0, 1, 2, 10, 11, 0, 1, 2}) {
+		t.Errorf("Concat returned %v", got)
+	}
+	words := enumerator.GetSliceEnumerable([]string{"a", "b"})
+	zipped := enumerator.Zip(a, words, func(i int, s string) string {
+		return fmt.Sprintf("%s%d", s, i)
+	})
+	if got := zipped.Values(); !reflect.DeepEqual(got, []string{"a0", "b1"}) {
+		t.Errorf("Zip returned %v", got)
+	}
+	flat := enumerator.FlatMap(a, func(i int) enumerator.IEnumerable[int] {
+		return enumerator.Take(enumerator.Repeat(i), i)
+	})
+	if got := flat.Values(); !reflect.DeepEqual(got, []int{1, 2, 2}) {
+		t.Errorf("FlatMap returned %v", got)
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	s := enumerator.GetSliceEnumerable([]int{3, 1, 3, 2, 1})
+	if got := enumerator.Distinct(s).Values(); !reflect.DeepEqual(got, []int{3, 1, 2}) {
+		t.Errorf("Distinct returned %v", got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	s := enumerator.GetSliceEnumerable([]string{"apple", "bean", "avocado", "corn", "banana"})
+	groups := enumerator.GroupBy(s, func(w string) byte { return w[0] }).Values()
+	expected := []enumerator.Grouping[byte, string]{
+		{Key: 'a', Values: []string{"apple", "avocado"}},
+		{Key: 'b', Values: []string{"bean", "banana"}},
+		{Key: 'c', Values: []string{"corn"}},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("GroupBy returned %v", groups)
+	}
+}
+
+type person struct {
+	name string
+	age  int
+}
+
+func TestOrderBy(t *testing.T) {
+	people := enumerator.GetSliceEnumerable([]person{
+		{"Carol", 30}, {"Alice", 25}, {"Bob", 30}, {"Dave", 25}, {"Alice", 20},
+	})
+	byAge := enumerator.OrderBy(people, func(p person) int { return p.age })
+	expected := []person{{"Alice", 20}, {"Alice", 25}, {"Dave", 25}, {"Carol", 30}, {"Bob", 30}}
+	if got := byAge.Values(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("OrderBy was not a stable sort, got %v", got)
+	}
+	byAgeThenName := enumerator.ThenBy(byAge, func(p person) string { return p.name })
+	expected = []person{{"Alice", 20}, {"Alice", 25}, {"Dave", 25}, {"Bob", 30}, {"Carol", 30}}
+	if got := byAgeThenName.Values(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("ThenBy returned %v", got)
+	}
+	byNameThenAgeDesc := enumerator.ThenByDescending(
+		enumerator.OrderByDescending(people, func(p person) string { return p.name }),
+		func(p person) int { return p.age },
+	)
+	expected = []person{{"Dave", 25}, {"Carol", 30}, {"Bob", 30}, {"Alice", 25}, {"Alice", 20}}
+	enum := byNameThenAgeDesc.GetEnumerator()
+	for i := 0; enum.Next(); i++ {
+		if enum.Current() != expected[i] {
+			t.Errorf("OrderByDescending and ThenByDescending returned %v at %d, expected %v", enum.Current(), i, expected[i])
+		}
+	}
+	if people.Values()[0].name != "Carol" {
+		t.Error("OrderBy modified the source")
+	}
+}
+
+func TestJoins(t *testing.T) {
+	type order struct {
+		customer string
+		item     string
+	}
+	customers := enumerator.GetSliceEnumerable([]string{"ann", "ben", "cal"})
+	orders := enumerator.GetSliceEnumerable([]order{
+		{"ben", "tea"}, {"ann", "jam"}, {"ben", "bun"}, {"zed", "pie"},
+	})
+	self := func(s string) string { return s }
+	byCustomer := func(o order) string { return o.customer }
+
+	joined := enumerator.Join(customers, orders, self, byCustomer, func(c string, o order) string {
+		return c + ":" + o.item
+	})
+	if got := joined.Values(); !reflect.DeepEqual(got, []string{"ann:jam", "ben:tea", "ben:bun"}) {
+		t.Errorf("Join returned %v", got)
+	}
+
+	grouped := enumerator.GroupJoin(customers, orders, self, byCustomer, func(c string, os []order) string {
+		items := make([]string, len(os))
+		for i, o := range os {
+			items[i] = o.item
+		}
+		return c + ":" + strings.Join(items, ",")
+	})
+	if got := grouped.Values(); !reflect.DeepEqual(got, []string{"ann:jam", "ben:tea,bun", "cal:"}) {
+		t.Errorf("GroupJoin returned %v", got)
+	}
+}
+
+func TestAggregates(t *testing.T) {
+	s := enumerator.GetSliceEnumerable(rand.Perm(PERM_SIZE))
+	if v, err := enumerator.Min(s); v != 0 || err != nil {
+		t.Errorf("Min returned %d (%v)", v, err)
+	}
+	if v, err := enumerator.Max(s); v != PERM_SIZE-1 || err != nil {
+		t.Errorf("Max returned %d (%v)", v, err)
+	}
+	if v, err := enumerator.Average(s); v != float64(PERM_SIZE-1)/2 || err != nil {
+		t.Errorf("Average returned %f (%v)", v, err)
+	}
+	concat, err := enumerator.Aggregate(enumerator.GetSliceEnumerable([]string{"a", "b", "c"}), func(acc, s string) string {
+		return acc + "-" + s
+	})
+	if concat != "a-b-c" || err != nil {
+		t.Errorf("Aggregate returned %s (%v)", concat, err)
+	}
+
+	empty := enumerator.GetSliceEnumerable([]float64{})
+	if _, err := enumerator.Min(empty); err == nil {
+		t.Error("Min did not error on an empty enumerable")
+	}
+	if _, err := enumerator.Max(empty); err == nil {
+		t.Error("Max did not error on an empty enumerable")
+	}
+	if _, err := enumerator.Average(empty); err == nil {
+		t.Error("Average did not error on an empty enumerable")
+	}
+}
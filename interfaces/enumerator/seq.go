@@ -0,0 +1,98 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+import (
+	"iter"
+	"runtime"
+)
+
+// Seq returns an iter.Seq over the elements of the enumerable, for use with range.
+//
+// A new enumerator is created each time the sequence is iterated, and
+// enumeration stops as soon as the loop body breaks.
+func Seq[V any](e IEnumerable[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		enum := e.GetEnumerator()
+		for enum.Next() {
+			if !yield(enum.Current()) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 returns an iter.Seq2 over the indices and elements of the enumerable, for use with range.
+func Seq2[V any](e IEnumerable[V]) iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		enum := e.GetEnumerator()
+		for i := 0; enum.Next(); i++ {
+			if !yield(i, enum.Current()) {
+				return
+			}
+		}
+	}
+}
+
+// FromSeq returns an enumerable of the values produced by the sequence.
+//
+// The sequence is pulled lazily with iter.Pull, one value per call to Next,
+// so infinite sequences can be bounded with LazyTake over the result. Each
+// enumerator starts a fresh pass over the sequence, and Reset starts over.
+//
+// An enumerator holds the paused sequence until it is exhausted or reset. One
+// that is abandoned early releases it once the enumerator is garbage collected.
+func FromSeq[V any](seq iter.Seq[V]) IEnumerable[V] {
+	return &lazyEnumerable[V]{func() IEnumerator[V] {
+		p := &pull[V]{seq: seq}
+		enum := &funcEnumerator[V]{
+			next:  p.pull,
+			reset: p.reset,
+		}
+		runtime.AddCleanup(enum, (*pull[V]).release, p)
+		return enum
+	}}
+}
+
+// pull is the state of a sequence being pulled by a FromSeq enumerator. It is
+// kept apart from the enumerator so a cleanup can release it.
+type pull[V any] struct {
+	seq       iter.Seq[V]
+	next      func() (V, bool)
+	stop      func()
+	exhausted bool
+}
+
+func (p *pull[V]) pull() (V, bool) {
+	if p.exhausted {
+		return *new(V), false
+	}
+	if p.next == nil {
+		p.next, p.stop = iter.Pull(p.seq)
+	}
+	v, ok := p.next()
+	if !ok {
+		p.exhausted = true
+		p.release()
+	}
+	return v, ok
+}
+
+func (p *pull[V]) reset() {
+	p.release()
+	p.exhausted = false
+}
+
+// release stops the paused sequence, if there is one.
+func (p *pull[V]) release() {
+	if p.stop != nil {
+		p.stop()
+	}
+	p.next, p.stop = nil, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+func TestSeq(t *testing.T) {
+	s := enumerator.Range(0, 10)
+	got := make([]int, 0)
+	for v := range enumerator.Seq(s) {
+		if v == 5 {
+			break
+		}
+		got = append(got, v)
+	}
+	if !reflect.DeepEqual(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Seq did not stop on break, got %v", got)
+	}
+
+	// Infinite enumerables are safe to range over with a break
+	sum := 0
+	for v := range enumerator.Seq(enumerator.Iterate(1, func(i int) int { return i + 1 })) {
+		if v > 100 {
+			break
+		}
+		sum += v
+	}
+	if sum != 5050 {
+		t.Errorf("Seq over an infinite enumerable summed to %d", sum)
+	}
+}
+
+func TestSeq2(t *testing.T) {
+	s := enumerator.GetSliceEnumerable([]string{"a", "b", "c"})
+	got := maps.Collect(enumerator.Seq2(s))
+	if !reflect.DeepEqual(got, map[int]string{0: "a", 1: "b", 2: "c"}) {
+		t.Errorf("Seq2 returned %v", got)
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	e := enumerator.FromSeq(slices.Values([]int{3, 1, 2}))
+	if !reflect.DeepEqual(e.Values(), []int{3, 1, 2}) {
+		t.Errorf("FromSeq returned %v", e.Values())
+	}
+	doubled := enumerator.Map(e, func(i int) int { return i * 2 })
+	if !reflect.DeepEqual(slices.Collect(enumerator.Seq(doubled)), []int{6, 2, 4}) {
+		t.Errorf("Round trip through FromSeq and Seq returned %v", doubled.Values())
+	}
+}
+
+func TestFromSeqLazy(t *testing.T) {
+	pulled := 0
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	e := enumerator.LazyTake(enumerator.FromSeq(naturals), 5)
+	if !reflect.DeepEqual(e.Values(), []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Expected LazyTake over an infinite sequence to return [0 1 2 3 4], got %v", e.Values())
+	}
+	if pulled != 5 {
+		t.Errorf("Expected LazyTake to pull only 5 values, pulled %d", pulled)
+	}
+
+	enum := enumerator.FromSeq(slices.Values([]int{1, 2})).GetEnumerator()
+	got := make([]int, 0)
+	for enum.Next() {
+		got = append(got, enum.Current())
+	}
+	if enum.Next() {
+		t.Error("Expected Next to keep returning false once exhausted")
+	}
+	enum.Reset()
+	for enum.Next() {
+		got = append(got, enum.Current())
+	}
+	if !reflect.DeepEqual(got, []int{1, 2, 1, 2}) {
+		t.Errorf("Expected Reset to start a fresh pass, got %v", got)
+	}
+}
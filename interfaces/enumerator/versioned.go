@@ -0,0 +1,54 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator
+
+import "errors"
+
+// ErrCollectionModified is the value a versioned enumerator panics with when
+// its collection was modified after the enumerator was created.
+var ErrCollectionModified = errors.New("collection was modified during enumeration")
+
+type versionedEnumerator[V any] struct {
+	inner   IEnumerator[V]
+	version func() int
+	start   int
+}
+
+// GetVersionedEnumerator wraps e so that it fails fast if its collection is modified.
+//
+// version must return the modification counter of the collection, which is
+// recorded when GetVersionedEnumerator is called. Next and Reset panic with
+// ErrCollectionModified once version returns a different value.
+func GetVersionedEnumerator[V any](e IEnumerator[V], version func() int) IEnumerator[V] {
+	return &versionedEnumerator[V]{
+		inner:   e,
+		version: version,
+		start:   version(),
+	}
+}
+
+func (e *versionedEnumerator[V]) Current() V {
+	return e.inner.Current()
+}
+
+func (e *versionedEnumerator[V]) Next() bool {
+	e.check()
+	return e.inner.Next()
+}
+
+func (e *versionedEnumerator[V]) Reset() {
+	e.check()
+	e.inner.Reset()
+}
+
+func (e *versionedEnumerator[V]) check() {
+	if e.version() != e.start {
+		panic(ErrCollectionModified)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright 2023 Christian Sigmon <cws@glasket.com>
+ *
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package enumerator_test
+
+import (
+	"testing"
+
+	"github.com/glasket/datastructures/interfaces/enumerator"
+)
+
+func TestVersionedEnumerator(t *testing.T) {
+	version := 0
+	e := enumerator.GetVersionedEnumerator(enumerator.Range(0, 3).GetEnumerator(), func() int {
+		return version
+	})
+
+	count := 0
+	for e.Next() {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Expected the versioned enumerator to yield 3 values, got %d", count)
+	}
+	e.Reset()
+	if !e.Next() || e.Current() != 0 {
+		t.Errorf("Expected Reset to restart the enumerator, got %d", e.Current())
+	}
+
+	version++
+	defer func() {
+		if r := recover(); r != enumerator.ErrCollectionModified {
+			t.Errorf("Expected Next to panic with ErrCollectionModified, got %v", r)
+		}
+	}()
+	e.Next()
+	t.Error("Expected Next to panic after the version changed")
+}
@@ -12,6 +12,10 @@ package iterator
 //
 // Iterable does not guarantee thread safety, and generally should not be used
 // when mutating the implementor.
+//
+// Deprecated: a channel based iterator leaks its sending goroutine if the
+// receiver stops early. Collections provide range-over-func iterators through
+// their All methods instead.
 type Iterable[V any] interface {
 	Iter() <-chan V
 }